@@ -26,8 +26,20 @@ const (
 	TestnetPrefix = "it"
 )
 
-// ErrInvalidAddr indicates the invalid address error
-var ErrInvalidAddr = errors.New("invalid address")
+// versions of the address format
+const (
+	// Version1 is the version of AddrV1 and AddrV1Special
+	Version1 = 1
+	// Version2 is the version of AddrV2
+	Version2 = 2
+)
+
+var (
+	// ErrInvalidAddr indicates the invalid address error
+	ErrInvalidAddr = errors.New("invalid address")
+	// ErrNoBytes indicates the address is not a 20-byte hash, e.g., a special address
+	ErrNoBytes = errors.New("address has no bytes")
+)
 
 var isTestNet bool
 
@@ -41,13 +53,56 @@ type Address interface {
 
 	// Hex is the hex-encoding of Bytes, prefixed with "0x"
 	Hex() string
+
+	// Version returns the version of the address format
+	Version() int
+}
+
+// SafeAddress is an address whose Bytes and Hex never panic, but return ErrNoBytes instead
+type SafeAddress interface {
+	Address
+
+	// TryBytes returns the underlying 20-byte public key hash, or ErrNoBytes
+	TryBytes() ([]byte, error)
+
+	// TryHex returns the hex-encoding of Bytes prefixed with "0x", or ErrNoBytes
+	TryHex() (string, error)
+}
+
+// FromString decodes an encoded address string of any version into an address struct
+func FromString(encodedAddr string) (Address, error) {
+	return FromStringOn(DefaultNetwork(), encodedAddr)
 }
 
-// FromString decodes an encoded address string into an address struct
-func FromString(encodedAddr string) (Address, error) { return _v1.FromString(encodedAddr) }
+// FromStringLegacy decodes an encoded address string of any version into an address struct
+func FromStringLegacy(encodedAddr string) (Address, error) {
+	return FromStringLegacyOn(DefaultNetwork(), encodedAddr)
+}
 
-// FromStringLegacy decodes an encoded address string into an address struct
-func FromStringLegacy(encodedAddr string) (Address, error) { return _v1.FromStringLegacy(encodedAddr) }
+// FromStringOn decodes an address string of any version encoded on the given network into an address struct
+func FromStringOn(net Network, encodedAddr string) (Address, error) {
+	if isV2String(net, encodedAddr) {
+		return fromStringV2(net, encodedAddr)
+	}
+	return _v1.FromStringOn(net, encodedAddr)
+}
+
+// FromStringLegacyOn decodes an address string of any version encoded on the given network into an address struct
+func FromStringLegacyOn(net Network, encodedAddr string) (Address, error) {
+	if isV2String(net, encodedAddr) {
+		return fromStringV2(net, encodedAddr)
+	}
+	return _v1.FromStringLegacyOn(net, encodedAddr)
+}
+
+// fromStringV2 decodes a V2 address string, returning a nil Address rather than a nil *AddrV2 on error
+func fromStringV2(net Network, encodedAddr string) (Address, error) {
+	addr, err := _v2.FromStringOn(net, encodedAddr)
+	if err != nil {
+		return nil, err
+	}
+	return addr, nil
+}
 
 // FromBytes converts a byte array into an address struct
 func FromBytes(bytes []byte) (Address, error) { return _v1.FromBytes(bytes) }
@@ -55,16 +110,43 @@ func FromBytes(bytes []byte) (Address, error) { return _v1.FromBytes(bytes) }
 // FromHex converts a hex-encoded string into an address struct
 func FromHex(s string) (Address, error) { return _v1.FromHex(s) }
 
-// prefix returns the current prefix
-func prefix() string {
-	prefix := MainnetPrefix
-	if isTestNet {
-		prefix = TestnetPrefix
+// FromHexChecked converts a "0x"-prefixed hex-encoded string into an address struct, verifying the EIP-55
+// checksum of a mixed-case string
+func FromHexChecked(s string) (Address, error) { return _v1.FromHexChecked(s) }
+
+// StringOn encodes the address into a string on the given network, or on the default network if it is the zero value
+// Addresses that are not bound to a network, such as special addresses, are returned as is
+func StringOn(net Network, addr Address) string {
+	if a, ok := addr.(interface{ StringOn(Network) string }); ok {
+		return a.StringOn(net)
 	}
-	return prefix
+	return addr.String()
+}
+
+// TryBytes returns the underlying 20-byte public key hash of the address, or ErrNoBytes
+func TryBytes(addr Address) ([]byte, error) {
+	if addr == nil {
+		return nil, ErrNoBytes
+	}
+	if a, ok := addr.(SafeAddress); ok {
+		return a.TryBytes()
+	}
+	return addr.Bytes(), nil
+}
+
+// TryHex returns the hex-encoding of the address's Bytes prefixed with "0x", or ErrNoBytes
+func TryHex(addr Address) (string, error) {
+	if addr == nil {
+		return "", ErrNoBytes
+	}
+	if a, ok := addr.(SafeAddress); ok {
+		return a.TryHex()
+	}
+	return addr.Hex(), nil
 }
 
 // Equal determine if two addresses are equal
+// Addresses without bytes, such as special addresses, are equal if their strings are equal
 func Equal(addr1 Address, addr2 Address) bool {
 	if addr1 == nil && addr2 == nil {
 		return true
@@ -72,5 +154,14 @@ func Equal(addr1 Address, addr2 Address) bool {
 	if addr1 != nil && addr2 == nil || addr1 == nil && addr2 != nil {
 		return false
 	}
-	return bytes.Equal(addr1.Bytes(), addr2.Bytes())
+	b1, err1 := TryBytes(addr1)
+	b2, err2 := TryBytes(addr2)
+	switch {
+	case err1 == nil && err2 == nil:
+		return bytes.Equal(b1, b2)
+	case err1 != nil && err2 != nil:
+		return addr1.String() == addr2.String()
+	default:
+		return false
+	}
 }
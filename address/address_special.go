@@ -1,6 +1,9 @@
 package address
 
-import "golang.org/x/crypto/sha3"
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+)
 
 const (
 	// ZeroAddress is the IoTeX address whose hash160 is all zero
@@ -26,15 +29,21 @@ var (
 )
 
 // hash160b returns 160-bit (20-byte) hash of input
-func hash160b(input []byte) Hash160 {
-	// use keccak algorithm
-	hasher := sha3.NewLegacyKeccak256()
-	hasher.Write(input)
+func hash160b(input ...[]byte) Hash160 {
 	var hash Hash160
-	copy(hash[:], hasher.Sum(nil)[12:])
+	copy(hash[:], keccak256(input...)[12:])
 	return hash
 }
 
+// keccak256 returns the 256-bit (32-byte) keccak hash of the concatenated input
+func keccak256(input ...[]byte) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	for _, b := range input {
+		hasher.Write(b)
+	}
+	return hasher.Sum(nil)
+}
+
 // bytesToHash160 copies the byte slice into hash
 func bytesToHash160(b []byte) Hash160 {
 	var h Hash160
@@ -57,25 +66,33 @@ func newAddrV1Special(s string) *AddrV1Special {
 	}
 }
 
-// IsAddrV1Special returns true for special address
+// IsAddrV1Special returns true for special address, including those added by RegisterSpecialAddr
 func IsAddrV1Special(s string) bool {
-	switch s {
-	case RewardingPoolAddr, StakingBucketPoolAddr:
-		return true
-	default:
-		return false
-	}
+	return _registry.isSpecial(s)
 }
 
 // String returns the special-text address
 func (addr *AddrV1Special) String() string { return addr.addr }
 
-// Bytes panics since it is NOT a valid bech32 encoding
+// Bytes panics since it is NOT a valid bech32 encoding, use TryBytes instead
 func (addr *AddrV1Special) Bytes() []byte {
 	panic("Bytes() does not apply for special address")
 }
 
-// Hex panics since it is NOT a valid bech32 encoding
+// Hex panics since it is NOT a valid bech32 encoding, use TryHex instead
 func (addr *AddrV1Special) Hex() string {
 	panic("Hex() does not apply for special address")
 }
+
+// Version returns 1
+func (addr *AddrV1Special) Version() int { return Version1 }
+
+// TryBytes returns ErrNoBytes since it is NOT a valid bech32 encoding
+func (addr *AddrV1Special) TryBytes() ([]byte, error) {
+	return nil, errors.Wrapf(ErrNoBytes, "special address %s", addr.addr)
+}
+
+// TryHex returns ErrNoBytes since it is NOT a valid bech32 encoding
+func (addr *AddrV1Special) TryHex() (string, error) {
+	return "", errors.Wrapf(ErrNoBytes, "special address %s", addr.addr)
+}
@@ -7,8 +7,10 @@
 package address
 
 import (
+	"encoding/binary"
 	"encoding/hex"
-	"log"
+	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 
@@ -18,6 +20,9 @@ import (
 // V1AddressStringLength is the length of v1 address string
 const V1AddressStringLength = 41
 
+// v1PayloadStringLength is the length of the separator, encoded 20-byte hash and checksum of v1 address string
+const v1PayloadStringLength = V1AddressStringLength - len(MainnetPrefix)
+
 // _v1 is a singleton and defines V1 address metadata
 var _v1 = v1{
 	AddressLength: 20,
@@ -30,25 +35,41 @@ type v1 struct {
 
 // FromString decodes an encoded address string into an address struct
 func (v *v1) FromString(encodedAddr string) (Address, error) {
+	return v.FromStringOn(DefaultNetwork(), encodedAddr)
+}
+
+// FromStringOn decodes an address string encoded on the given network into an address struct
+func (v *v1) FromStringOn(net Network, encodedAddr string) (Address, error) {
+	if !net.IsValid() {
+		return nil, ErrInvalidNetwork
+	}
 	if IsAddrV1Special(encodedAddr) {
 		return newAddrV1Special(encodedAddr), nil
 	}
-	if len(encodedAddr) != V1AddressStringLength {
-		return nil, errors.Wrapf(ErrInvalidAddr, "address length = %d, expecting 41", len(encodedAddr))
+	if expected := len(net.Prefix()) + v1PayloadStringLength; len(encodedAddr) != expected {
+		return nil, &LengthError{Got: len(encodedAddr), Want: expected}
 	}
-	payload, err := v.decodeBech32(encodedAddr)
+	payload, err := v.decodeBech32(net.Prefix(), encodedAddr)
 	if err != nil {
 		return nil, err
 	}
 	return v.FromBytes(payload)
 }
 
-// FromStringLeacy decodes an encoded address string into an address struct
+// FromStringLegacy decodes an encoded address string into an address struct
 func (v *v1) FromStringLegacy(encodedAddr string) (Address, error) {
+	return v.FromStringLegacyOn(DefaultNetwork(), encodedAddr)
+}
+
+// FromStringLegacyOn decodes an address string encoded on the given network into an address struct
+func (v *v1) FromStringLegacyOn(net Network, encodedAddr string) (Address, error) {
+	if !net.IsValid() {
+		return nil, ErrInvalidNetwork
+	}
 	if IsAddrV1Special(encodedAddr) {
 		return newAddrV1Special(encodedAddr), nil
 	}
-	payload, err := v.decodeBech32Legacy(encodedAddr)
+	payload, err := v.decodeBech32Legacy(net.Prefix(), encodedAddr)
 	if err != nil {
 		return nil, err
 	}
@@ -84,31 +105,72 @@ func (v *v1) FromHex(s string) (Address, error) {
 	return v.FromBytes(bytes)
 }
 
-func (v *v1) decodeBech32(encodedAddr string) ([]byte, error) {
-	hrp, grouped, err := bech32.Decode(encodedAddr)
+// FromHexChecked converts a "0x"-prefixed hex-encoded string of 20 bytes into an address struct
+// A mixed-case string must match the EIP-55 checksum, while an all-lowercase or all-uppercase string carries no checksum
+func (v *v1) FromHexChecked(s string) (Address, error) {
+	if len(s) != 2+2*v.AddressLength {
+		return nil, &LengthError{Got: len(s), Want: 2 + 2*v.AddressLength}
+	}
+	if s[0] != '0' || (s[1] != 'x' && s[1] != 'X') {
+		return nil, errors.Wrapf(ErrInvalidAddr, "hex address %s is not 0x-prefixed", s)
+	}
+	digits := s[2:]
+	for i := 0; i < len(digits); i++ {
+		if c := digits[i]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return nil, &CharError{Pos: 2 + i, Char: c}
+		}
+	}
+	addr, err := v.FromHex(s)
 	if err != nil {
 		return nil, errors.Wrapf(ErrInvalidAddr, err.Error())
 	}
-	if hrp != prefix() {
-		return nil, errors.Wrapf(ErrInvalidAddr, "hrp %s and address prefix %s don't match", hrp, prefix())
+	if digits == strings.ToLower(digits) || digits == strings.ToUpper(digits) {
+		return addr, nil
+	}
+	if expected := addr.(*AddrV1).ChecksumHex(); expected[2:] != digits {
+		return nil, &ChecksumError{Expected: expected, Got: s}
+	}
+	return addr, nil
+}
+
+func (v *v1) decodeBech32(prefix, encodedAddr string) ([]byte, error) {
+	hrp, grouped, err := bech32.Decode(encodedAddr)
+	if err != nil {
+		return nil, fromBech32Error(err, encodedAddr, len(prefix)+v1PayloadStringLength)
+	}
+	if hrp != prefix {
+		return nil, &HRPError{Got: hrp, Want: prefix}
 	}
 	// Group the payload into 8 bit groups.
 	payload, err := bech32.ConvertBits(grouped, 5, 8, false)
 	if err != nil {
-		return nil, errors.Wrapf(ErrInvalidAddr, err.Error())
+		return nil, fromBech32Error(err, encodedAddr, len(prefix)+v1PayloadStringLength)
 	}
 	return payload, nil
 }
 
-func (v *v1) decodeBech32Legacy(encodedAddr string) ([]byte, error) {
+// decodeBech32Legacy decodes an address string of the historical encodings, which are
+//   - the bech32 encoding of a 20-byte hash, i.e., the v1 address
+//   - the bech32 encoding of a payload longer than 20 bytes, e.g., a hash prefixed with version and chain ID bytes
+//     of the pre-v1 addresses, of which the last 20 bytes are the hash
+//
+// Any other string is rejected, i.e., an invalid bech32 string, a bech32m string, a prefix other than the network's,
+// an incomplete group of non-zero bits, and a payload shorter than 20 bytes
+func (v *v1) decodeBech32Legacy(prefix, encodedAddr string) ([]byte, error) {
 	hrp, grouped, err := bech32.Decode(encodedAddr)
-	if hrp != prefix() {
-		return nil, errors.Wrapf(err, "hrp %s and address prefix %s don't match", hrp, prefix())
+	if err != nil {
+		return nil, fromBech32Error(err, encodedAddr, len(prefix)+v1PayloadStringLength)
+	}
+	if hrp != prefix {
+		return nil, &HRPError{Got: hrp, Want: prefix}
 	}
 	// Group the payload into 8 bit groups.
 	payload, err := bech32.ConvertBits(grouped, 5, 8, false)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error when converting 5 bit groups into the payload")
+		return nil, fromBech32Error(err, encodedAddr, len(prefix)+v1PayloadStringLength)
+	}
+	if len(payload) < v.AddressLength {
+		return nil, &LengthError{Got: len(encodedAddr), Want: len(prefix) + v1PayloadStringLength}
 	}
 	return payload, nil
 }
@@ -122,23 +184,70 @@ type (
 	AddrV1 struct {
 		payload Hash160
 	}
+
+	// addrCache memoizes the bech32 strings of addresses in a fixed number of slots, the slot of an address being
+	// selected by its payload, which is uniformly distributed
+	// An address replaces the one in its slot, so that the cache stays bounded without allocating or maintaining a
+	// list on each call as a LRU cache does, and a miss costs little more than the encoding. The slots are sharded,
+	// so that concurrent calls on different addresses rarely contend
+	addrCache struct {
+		shards [addrCacheShards]addrCacheShard
+	}
+
+	addrCacheShard struct {
+		mtx   sync.Mutex
+		slots [addrCacheShardSlots]addrCacheSlot
+	}
+
+	// addrCacheSlot holds the string of the payload on the network prefix, an empty string being an empty slot
+	addrCacheSlot struct {
+		payload Hash160
+		prefix  string
+		s       string
+	}
+)
+
+const (
+	// addrCacheShards is the number of shards of the cache
+	addrCacheShards = 64
+	// addrCacheShardSlots is the number of slots of a shard
+	addrCacheShardSlots = 256
 )
 
+// _addrCache is shared by all addresses, so that AddrV1 stays a comparable value
+var _addrCache addrCache
+
 // String encodes an address struct into a a String encoded address string
 // The encoded address string will start with "io" for mainnet, and with "it" for testnet
 func (addr *AddrV1) String() string {
-	payload := addr.payload[:]
-	// Group the payload into 5 bit groups.
-	grouped, err := bech32.ConvertBits(payload, 8, 5, true)
-	if err != nil {
-		log.Panic("Error when grouping the payload into 5 bit groups." + err.Error())
-		return ""
-	}
-	encodedAddr, err := bech32.Encode(prefix(), grouped)
-	if err != nil {
-		log.Panic("Error when encoding bytes into a base32 string." + err.Error())
-		return ""
+	return addr.StringOn(DefaultNetwork())
+}
+
+// StringOn encodes an address struct into a String encoded address string on the given network, or on the default
+// network if it is the zero value
+// The result is memoized per network, and it is safe to call StringOn concurrently
+func (addr *AddrV1) StringOn(net Network) string {
+	net = net.orDefault()
+	if s, ok := _addrCache.get(addr.payload, net.Prefix()); ok {
+		return s
 	}
+	s := addr.encode(net.Prefix())
+	_addrCache.set(addr.payload, net.Prefix(), s)
+	return s
+}
+
+// encode returns the String encoded address with the prefix
+func (addr *AddrV1) encode(prefix string) string {
+	var buf [90]byte
+	return string(addr.appendString(buf[:0], prefix))
+}
+
+// appendString appends the String encoded address with the prefix to dst
+func (addr *AddrV1) appendString(dst []byte, prefix string) []byte {
+	// Group the payload into 5 bit groups, and encode them. Neither can fail for a 20-byte payload
+	var groupedBuf [32]byte
+	grouped, _ := bech32.AppendConvertBits(groupedBuf[:0], addr.payload[:], 8, 5, true)
+	encodedAddr, _ := bech32.AppendEncode(dst, prefix, grouped)
 	return encodedAddr
 }
 
@@ -149,5 +258,71 @@ func (addr *AddrV1) Bytes() []byte {
 
 // Hex is the hex-encoding of Bytes, prefixed with "0x"
 func (addr *AddrV1) Hex() string {
-	return "0x" + hex.EncodeToString(addr.payload[:])
+	var buf [2 + 2*len(Hash160{})]byte
+	copy(buf[:], "0x")
+	hex.Encode(buf[2:], addr.payload[:])
+	return string(buf[:])
+}
+
+func (c *addrCache) get(payload Hash160, prefix string) (string, bool) {
+	shard, i := c.slot(payload)
+	shard.mtx.Lock()
+	defer shard.mtx.Unlock()
+	if slot := &shard.slots[i]; slot.s != "" && slot.payload == payload && slot.prefix == prefix {
+		return slot.s, true
+	}
+	return "", false
+}
+
+func (c *addrCache) set(payload Hash160, prefix, s string) {
+	shard, i := c.slot(payload)
+	shard.mtx.Lock()
+	defer shard.mtx.Unlock()
+	shard.slots[i] = addrCacheSlot{payload: payload, prefix: prefix, s: s}
+}
+
+// slot returns the shard and the index of the slot of the payload
+func (c *addrCache) slot(payload Hash160) (*addrCacheShard, int) {
+	n := binary.LittleEndian.Uint32(payload[:4])
+	return &c.shards[n%addrCacheShards], int(n / addrCacheShards % addrCacheShardSlots)
+}
+
+// TryBytes converts an address struct into a byte array, it never fails
+func (addr *AddrV1) TryBytes() ([]byte, error) {
+	return addr.Bytes(), nil
+}
+
+// TryHex is the hex-encoding of Bytes, prefixed with "0x", it never fails
+func (addr *AddrV1) TryHex() (string, error) {
+	return addr.Hex(), nil
+}
+
+// Version returns 1
+func (addr *AddrV1) Version() int { return Version1 }
+
+// ChecksumHex is the mixed-case hex-encoding of Bytes with EIP-55 checksum, prefixed with "0x"
+func (addr *AddrV1) ChecksumHex() string {
+	digits := []byte(hex.EncodeToString(addr.payload[:]))
+	hash := keccak256(digits)
+	for i, c := range digits {
+		// uppercase a letter if the corresponding nibble of the hash is 8 or greater
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if c > '9' && nibble&0xf >= 8 {
+			digits[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(digits)
+}
+
+// hasUpper returns true if s contains an uppercase letter
+func hasUpper(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 'A' && s[i] <= 'Z' {
+			return true
+		}
+	}
+	return false
 }
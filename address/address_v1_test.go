@@ -8,11 +8,15 @@ package address
 
 import (
 	"crypto/rand"
+	"encoding/binary"
 	"io"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -103,7 +107,28 @@ func TestSpecialAddress(t *testing.T) {
 		require.Panics(func() {
 			addr.Hex()
 		})
+		b, err := TryBytes(addr)
+		require.Nil(b)
+		require.True(errors.Is(err, ErrNoBytes))
+		h, err := TryHex(addr)
+		require.Empty(h)
+		require.True(errors.Is(err, ErrNoBytes))
+
+		// helpers do not panic on special address
+		require.NotPanics(func() {
+			require.True(Equal(addr, newAddrV1Special(v)))
+			require.False(Equal(addr, &AddrV1{}))
+			require.False(Equal(&AddrV1{}, addr))
+			require.False(Equal(addr, nil))
+			_, err = ContractAddress(addr, 0)
+			require.True(errors.Is(err, ErrInvalidAddr))
+			_, err = Create2Address(addr, [HashLength]byte{}, InitCodeHash(nil))
+			require.True(errors.Is(err, ErrInvalidAddr))
+		})
 	}
+	require.False(Equal(newAddrV1Special(RewardingPoolAddr), newAddrV1Special(StakingBucketPoolAddr)))
+	_, err := TryBytes(nil)
+	require.Equal(ErrNoBytes, err)
 
 	// special address for staking actions
 	tests := []struct {
@@ -118,6 +143,12 @@ func TestSpecialAddress(t *testing.T) {
 		require.False(IsAddrV1Special(test.addr))
 		addr, _ := _v1.FromBytes(test.hashByte[:])
 		require.Equal(test.addr, addr.String())
+		b, err := TryBytes(addr)
+		require.NoError(err)
+		require.Equal(test.hashByte[:], b)
+		h, err := TryHex(addr)
+		require.NoError(err)
+		require.Equal(addr.Hex(), h)
 		addr, _ = _v1.FromString(test.addr)
 		require.Equal(test.hashByte[:], addr.Bytes())
 	}
@@ -136,20 +167,20 @@ func TestLegacyFormat(t *testing.T) {
 			"checksum failed: Expected anqr4d", "address length = 64",
 			""},
 		{"iota1qp3mxh8gx8fkqmss9c6jsm979wuv6qpm0waw6vhxt0dwzze8xxzkqanqr4d", // wrong hrp, right checksum, long size
-			"", "address length = 64",
-			ZeroAddress},
+			"hrp iota and address prefix io don't match", "address length = 64",
+			""},
 		{"iota1qp3mxh8gx8fkqmss9c6jsm979wuv6qpm0w", // wrong hrp, wrong checksum, short size
 			"checksum failed: Expected 5a73lu", "address length = 39",
 			""},
 		{"iota1qp3mxh8gx8fkqmss9c6jsm979wuv5a73lu", // wrong hrp, right checksum, short size
-			"", "address length = 39",
-			ZeroAddress},
+			"hrp iota and address prefix io don't match", "address length = 39",
+			""},
 		{"iota1qp3mxh8gx8fkqmss9c6jsm979wuv6qpm0waw", // wrong hrp, wrong checksum, right size
 			"checksum failed: Expected 06dmq2", "checksum failed: Expected 06dmq2",
 			""},
 		{"iota1qp3mxh8gx8fkqmss9c6jsm979wuv6q06dmq2", // wrong hrp, right checksum, right size
-			"", "hrp iota and address prefix io don't match",
-			ZeroAddress},
+			"hrp iota and address prefix io don't match", "hrp iota and address prefix io don't match",
+			""},
 		{"io1qp3mxh8gx8fkqmss9c6jsm979wuv6qpm0waw6vhxt0dwzze8xxzkqanqr4d", // right hrp, wrong checksum, long size
 			"checksum failed: Expected zy3lxu", "address length = 62",
 			""},
@@ -172,6 +203,7 @@ func TestLegacyFormat(t *testing.T) {
 		a, err := FromStringLegacy(v.addr)
 		if v.errLegacy != "" {
 			r.Contains(err.Error(), v.errLegacy)
+			r.Nil(a)
 		} else {
 			r.Equal(v.nominal, a.String())
 		}
@@ -185,3 +217,238 @@ func TestLegacyFormat(t *testing.T) {
 	}
 	r.Equal(1, success) // only 1 valid address in all tests
 }
+
+func TestLegacyCorpus(t *testing.T) {
+	r := require.New(t)
+
+	// historical addresses which must still decode
+	for _, v := range []struct {
+		net          Network
+		addr, hexStr string
+	}{
+		// v1 addresses of the README, the protocols and the zero address
+		{Mainnet, "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5", "0x6cbe2bb8cd003b7bbaed32e65bdae10b27318560"},
+		{Mainnet, "io154mvzs09vkgn0hw6gg3ayzw5w39jzp47f8py9v", "0xa576c141e5659137ddda4223d209d4744b2106be"},
+		{Mainnet, "io1nyjs526mnqcsx4twa7nptkg08eclsw5c2dywp4", "0x99250a2b5b983103556eefa615d90f3e71f83a98"},
+		{Mainnet, "io1qnpz47hx5q6r3w876axtrn6yz95d70cjl35r53", "0x04c22afae6a03438b8fed74cb1cf441168df3f12"},
+		{Mainnet, "io1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqd39ym7", "0x0000000000000000000000000000000000000000"},
+		// pre-v1 mainnet addresses of the version byte 1, chain ID bytes 01020304 and hash
+		{Mainnet, "io1qyqsyqcy6nm58gjd2wr035wz5eyd5uq47zyqpng3gxe7nh", "0xd4f743a24d5386f8d1c2a648da7015f08800cd11"},
+		{Mainnet, "io1qyqsyqcy3kcd2pyfwus69nzgvkwhg8mk8h336dt86pg6cj", "0x8db0d504897721a2cc48659d741f763de31d3567"},
+		{Mainnet, "io1qyqsyqcy8uhx9jtdc2xp5wx7nxyq3xf4c3jmxknzkuej8y", "0x3f2e62c96dc28c1a38de9988089935c465b35a62"},
+		// synthetic: uppercase and testnet encodings of the same hash
+		{Mainnet, "IO1DJLZHWXDQQAHHWHDXTN9HKHPPVNNRPTQTWF2H5", "0x6cbe2bb8cd003b7bbaed32e65bdae10b27318560"},
+		{Testnet, "it1djlzhwxdqqahhwhdxtn9hkhppvnnrptqg05fuh", "0x6cbe2bb8cd003b7bbaed32e65bdae10b27318560"},
+		// synthetic: a 33-byte payload
+		{Mainnet, "io1qp3mxh8gx8fkqmss9c6jsm979wuv6qpm0waw6vhxt0dwzze8xxzkqzy3lxu", "0x6cbe2bb8cd003b7bbaed32e65bdae10b27318560"},
+		// synthetic: pre-v1 layouts of other chain IDs, on mainnet and testnet
+		{Mainnet, "io1qyqqqqqpdjlzhwxdqqahhwhdxtn9hkhppvnnrptqcp7046", "0x6cbe2bb8cd003b7bbaed32e65bdae10b27318560"},
+		{Testnet, "it1qyqqqqqzdjlzhwxdqqahhwhdxtn9hkhppvnnrptqjxnn6d", "0x6cbe2bb8cd003b7bbaed32e65bdae10b27318560"},
+	} {
+		addr, err := FromStringLegacyOn(v.net, v.addr)
+		r.NoError(err, v.addr)
+		r.Equal(v.hexStr, addr.Hex())
+	}
+
+	// everything else is rejected with a typed error
+	var (
+		lengthErr   *LengthError
+		charErr     *CharError
+		mixedErr    *MixedCaseError
+		checksumErr *ChecksumError
+		hrpErr      *HRPError
+		paddingErr  *PaddingError
+	)
+	for _, v := range []struct {
+		addr   string
+		target interface{}
+	}{
+		{"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptq7jexjk", &checksumErr}, // bech32m
+		{"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h4", &checksumErr},
+		{"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2hb", &charErr},
+		{"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2H5", &mixedErr},
+		{"it1djlzhwxdqqahhwhdxtn9hkhppvnnrptqg05fuh", &hrpErr},
+		{"iota1qp3mxh8gx8fkqmss9c6jsm979wuv6qpm0waw6vhxt0dwzze8xxzkqanqr4d", &hrpErr},
+		{"io1djlzhwxdqqahhwhdxtn9hkhptjxuew", &lengthErr}, // 15-byte payload
+		{"io1qp3mxh8gx8fkqmss9c6jsm979wuv6qpm0waw6vhxt0dwzze8xxzkplj92mw", &paddingErr},
+		{"io1djlzhwxdqqahhwhdxtn9hkhppvnnrp726csn", &paddingErr},
+	} {
+		addr, err := FromStringLegacy(v.addr)
+		r.Nil(addr)
+		r.True(errors.Is(err, ErrInvalidAddr), v.addr)
+		r.True(errors.As(err, v.target), v.addr)
+	}
+	for _, s := range []string{"", "io", "io1", "1qqqqqq", "io1qqqqqq"} {
+		addr, err := FromStringLegacy(s)
+		r.Nil(addr)
+		r.True(errors.Is(err, ErrInvalidAddr), s)
+	}
+}
+
+func TestChecksumHex(t *testing.T) {
+	r := require.New(t)
+
+	// test vectors from https://eips.ethereum.org/EIPS/eip-55
+	for _, v := range []string{
+		"0x52908400098527886E0F7030069857D2E4169EE7",
+		"0x8617E340B3D01FA5F11F306F4090FD50E238070D",
+		"0xde709f2102306220921060314715629080e2fb77",
+		"0x27b1fdb04752bbc536007a920d24acb045561c26",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		addr, err := FromHexChecked(v)
+		r.NoError(err)
+		r.Equal(v, addr.(*AddrV1).ChecksumHex())
+		r.Equal(strings.ToLower(v), addr.Hex())
+
+		// all-lowercase and all-uppercase strings carry no checksum
+		_, err = FromHexChecked(strings.ToLower(v))
+		r.NoError(err)
+		_, err = FromHexChecked("0x" + strings.ToUpper(v[2:]))
+		r.NoError(err)
+	}
+
+	for _, v := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", // wrong checksum
+		"0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", // wrong checksum
+		"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",   // missing 0x
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA",   // too short
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAgd", // not hex
+	} {
+		_, err := FromHexChecked(v)
+		r.Error(err)
+		r.Equal(ErrInvalidAddr, errors.Cause(err))
+	}
+}
+
+func BenchmarkAddrV1String(b *testing.B) {
+	addr, err := FromBytes(StakingProtocolAddrHash[:])
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = addr.String()
+	}
+}
+
+// distinctAddrs returns n addresses of distinct random hashes, as seen by an indexer
+func distinctAddrs(n int) []AddrV1 {
+	addrs := make([]AddrV1, n)
+	for i := range addrs {
+		binary.BigEndian.PutUint64(addrs[i].payload[:], uint64(i))
+		addrs[i].payload = hash160b(addrs[i].payload[:])
+	}
+	return addrs
+}
+
+func BenchmarkAddrV1StringDistinct(b *testing.B) {
+	addrs := distinctAddrs(1 << 20)
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = addrs[i&(len(addrs)-1)].String()
+		}
+	})
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = addrs[i&(len(addrs)-1)].encode(MainnetPrefix)
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		b.ReportAllocs()
+		var next uint32
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = addrs[atomic.AddUint32(&next, 1)&uint32(len(addrs)-1)].String()
+			}
+		})
+	})
+}
+
+func TestAddrV1Cache(t *testing.T) {
+	r := require.New(t)
+
+	// zero value still encodes
+	var zero AddrV1
+	r.NotPanics(func() {
+		r.Equal(ZeroAddress, zero.StringOn(Mainnet))
+		r.Equal("0x0000000000000000000000000000000000000000", zero.Hex())
+	})
+
+	addr, err := FromBytes(StakingProtocolAddrHash[:])
+	r.NoError(err)
+	v1 := addr.(*AddrV1)
+	testnetAddr := v1.encode(TestnetPrefix)
+	var (
+		wg     sync.WaitGroup
+		failed int32
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if v1.StringOn(Mainnet) != StakingProtocolAddr || v1.StringOn(Testnet) != testnetAddr ||
+					v1.Hex() != "0x04c22afae6a03438b8fed74cb1cf441168df3f12" {
+					atomic.StoreInt32(&failed, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	r.Zero(failed)
+	s, ok := _addrCache.get(v1.payload, TestnetPrefix)
+	r.True(ok)
+	r.Equal(testnetAddr, s)
+	r.Zero(testing.AllocsPerRun(10, func() { _ = v1.StringOn(Mainnet) }))
+
+	// addresses stay comparable values, and the cache follows the payload
+	other, err := FromString(StakingProtocolAddr)
+	r.NoError(err)
+	r.True(*v1 == *other.(*AddrV1))
+	copied := *v1
+	copied.Bytes()[0] ^= 0xff
+	r.NotEqual(StakingProtocolAddr, copied.String())
+	r.Equal(copied.encode(MainnetPrefix), copied.String())
+	r.Equal(StakingProtocolAddr, v1.String())
+
+	// decoding and hex encoding do not touch the cache
+	var h Hash160
+	h[0] = 0xab
+	lower := (&AddrV1{payload: h}).encode(MainnetPrefix)
+	_, err = FromStringOn(Mainnet, lower)
+	r.NoError(err)
+	_ = (&AddrV1{payload: h}).Hex()
+	_, ok = _addrCache.get(h, MainnetPrefix)
+	r.False(ok)
+	r.Equal("0xab00000000000000000000000000000000000000", (&AddrV1{payload: h}).Hex())
+
+	// the cache is bounded, an address replacing the one of the same slot
+	var c addrCache
+	first := Hash160{1}
+	c.set(first, MainnetPrefix, "first")
+	s, ok = c.get(first, MainnetPrefix)
+	r.True(ok)
+	r.Equal("first", s)
+	_, ok = c.get(first, TestnetPrefix)
+	r.False(ok)
+	second := first
+	second[19] = 1
+	_, ok = c.get(second, MainnetPrefix)
+	r.False(ok)
+	c.set(second, MainnetPrefix, "second")
+	_, ok = c.get(first, MainnetPrefix)
+	r.False(ok)
+	third := first
+	third[0] += addrCacheShards
+	c.set(third, MainnetPrefix, "third")
+	s, ok = c.get(second, MainnetPrefix)
+	r.True(ok)
+	r.Equal("second", s)
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-address/address/bech32"
+)
+
+// The V2 address string is the bech32m encoding of the payload
+//
+//	version (1 byte) | type (1 byte) | chain ID (4 bytes, big-endian, omitted if 0) | hash (20 bytes)
+//
+// The bech32m checksum tells a V2 string from a V1 string of the same network, which uses bech32.
+const (
+	// v2PayloadStringLength is the length of the separator, encoded payload without chain ID and checksum
+	v2PayloadStringLength = 1 + 36 + 6
+	// v2ChainPayloadStringLength is the length of the separator, encoded payload with chain ID and checksum
+	v2ChainPayloadStringLength = 1 + 42 + 6
+)
+
+// AddrType is the type of V2 address
+type AddrType uint8
+
+const (
+	// AccountType is the address of an account controlled by a private key
+	AccountType AddrType = iota
+	// ContractType is the address of a smart contract
+	ContractType
+	// ProtocolType is the address of a system protocol, e.g., staking or rewarding
+	ProtocolType
+	// MultisigType is the address of a multi-signature account
+	MultisigType
+)
+
+// String returns the name of the type
+func (t AddrType) String() string {
+	switch t {
+	case AccountType:
+		return "account"
+	case ContractType:
+		return "contract"
+	case ProtocolType:
+		return "protocol"
+	case MultisigType:
+		return "multisig"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(t))
+	}
+}
+
+// IsValid returns true for a known type
+func (t AddrType) IsValid() bool {
+	return t <= MultisigType
+}
+
+// _v2 is a singleton and defines V2 address metadata
+var _v2 = v2{
+	AddressLength: 20,
+}
+
+type v2 struct {
+	// AddressLength indicates the byte length of the hash
+	AddressLength int
+}
+
+// New creates a V2 address of the type and chain ID from a 20-byte hash, chain ID 0 means no chain ID
+func (v *v2) New(t AddrType, chainID uint32, hash []byte) (*AddrV2, error) {
+	if !t.IsValid() {
+		return nil, errors.Wrapf(ErrInvalidAddr, "unknown address type %d", t)
+	}
+	if len(hash) != v.AddressLength {
+		return nil, errors.Wrapf(ErrInvalidAddr, "hash length = %d, expecting %d", len(hash), v.AddressLength)
+	}
+	addr := AddrV2{typ: t, chainID: chainID}
+	copy(addr.payload[:], hash)
+	return &addr, nil
+}
+
+// FromStringOn decodes a V2 address string encoded on the given network into an address struct
+func (v *v2) FromStringOn(net Network, encodedAddr string) (*AddrV2, error) {
+	if !net.IsValid() {
+		return nil, ErrInvalidNetwork
+	}
+	// the length is either of a V2 address without or with chain ID, which is expected by the bech32 errors below
+	switch len(encodedAddr) - len(net.Prefix()) {
+	case v2PayloadStringLength, v2ChainPayloadStringLength:
+	default:
+		return nil, &LengthError{Got: len(encodedAddr), Want: len(net.Prefix()) + v2PayloadStringLength}
+	}
+	hrp, grouped, err := bech32.DecodeM(encodedAddr)
+	if err != nil {
+		return nil, fromBech32Error(err, encodedAddr, len(encodedAddr))
+	}
+	if hrp != net.Prefix() {
+		return nil, &HRPError{Got: hrp, Want: net.Prefix()}
+	}
+	payload, err := bech32.ConvertBits(grouped, 5, 8, false)
+	if err != nil {
+		return nil, fromBech32Error(err, encodedAddr, len(encodedAddr))
+	}
+	if payload[0] != Version2 {
+		return nil, &VersionError{Version: int(payload[0])}
+	}
+	t, payload := AddrType(payload[1]), payload[2:]
+	var chainID uint32
+	if len(payload) > v.AddressLength {
+		chainID = binary.BigEndian.Uint32(payload)
+		if chainID == 0 {
+			return nil, errors.Wrap(ErrInvalidAddr, "chain ID 0 must be omitted")
+		}
+		payload = payload[4:]
+	}
+	return v.New(t, chainID, payload)
+}
+
+// isV2String returns true if the string has the length of a V2 address on the network, and the bech32m checksum
+func isV2String(net Network, encodedAddr string) bool {
+	switch len(encodedAddr) - len(net.Prefix()) {
+	case v2PayloadStringLength, v2ChainPayloadStringLength:
+		_, _, version, err := bech32.DecodeGeneric(encodedAddr)
+		return err == nil && version == bech32.Bech32m
+	default:
+		return false
+	}
+}
+
+// AddrV2 is V2 address format, which carries the type and optionally the chain ID along with the 20-byte hash
+type AddrV2 struct {
+	typ     AddrType
+	chainID uint32
+	payload Hash160
+}
+
+// NewAddrV2 creates a V2 address of the type and chain ID from a 20-byte hash, chain ID 0 means no chain ID
+func NewAddrV2(t AddrType, chainID uint32, hash []byte) (*AddrV2, error) {
+	return _v2.New(t, chainID, hash)
+}
+
+// ToV2 converts an address into a V2 address of the type and chain ID
+// A V1 address carries neither, so they must be given by the caller. A V2 address is converted only if its type is
+// the given type, and its chain ID is either the given chain ID or 0. Special addresses have no hash and return
+// ErrNoBytes
+func ToV2(addr Address, t AddrType, chainID uint32) (*AddrV2, error) {
+	if a, ok := addr.(*AddrV2); ok {
+		if a.typ != t || a.chainID != 0 && a.chainID != chainID {
+			return nil, errors.Wrapf(ErrInvalidAddr, "%s is a %s address of chain %d", a, a.typ, a.chainID)
+		}
+	}
+	b, err := TryBytes(addr)
+	if err != nil {
+		return nil, err
+	}
+	return NewAddrV2(t, chainID, b)
+}
+
+// ToV1 converts an address into a V1 address of the same hash, dropping the type and chain ID of a V2 address
+// Special addresses have no hash and return ErrNoBytes
+func ToV1(addr Address) (*AddrV1, error) {
+	b, err := TryBytes(addr)
+	if err != nil {
+		return nil, err
+	}
+	a, err := FromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	return a.(*AddrV1), nil
+}
+
+// String encodes the address into a bech32m string on the default network
+func (addr *AddrV2) String() string {
+	return addr.StringOn(DefaultNetwork())
+}
+
+// StringOn encodes the address into a bech32m string on the given network, or on the default network if it is the
+// zero value
+func (addr *AddrV2) StringOn(net Network) string {
+	net = net.orDefault()
+	var buf [2 + 4 + 20]byte
+	buf[0], buf[1] = Version2, byte(addr.typ)
+	payload := buf[:2]
+	if addr.chainID != 0 {
+		payload = payload[:6]
+		binary.BigEndian.PutUint32(payload[2:], addr.chainID)
+	}
+	payload = append(payload, addr.payload[:]...)
+	// Neither can fail for a payload of at most 26 bytes
+	var groupedBuf [42]byte
+	grouped, _ := bech32.AppendConvertBits(groupedBuf[:0], payload, 8, 5, true)
+	var strBuf [90]byte
+	encodedAddr, _ := bech32.AppendEncodeM(strBuf[:0], net.Prefix(), grouped)
+	return string(encodedAddr)
+}
+
+// Bytes returns the underlying 20-byte hash
+func (addr *AddrV2) Bytes() []byte {
+	return addr.payload[:]
+}
+
+// Hex is the hex-encoding of Bytes, prefixed with "0x"
+func (addr *AddrV2) Hex() string {
+	return "0x" + hex.EncodeToString(addr.payload[:])
+}
+
+// TryBytes returns the underlying 20-byte hash, it never fails
+func (addr *AddrV2) TryBytes() ([]byte, error) {
+	return addr.Bytes(), nil
+}
+
+// TryHex is the hex-encoding of Bytes, prefixed with "0x", it never fails
+func (addr *AddrV2) TryHex() (string, error) {
+	return addr.Hex(), nil
+}
+
+// Version returns 2
+func (addr *AddrV2) Version() int { return Version2 }
+
+// Type returns the type of the address
+func (addr *AddrV2) Type() AddrType { return addr.typ }
+
+// ChainID returns the chain ID of the address, or 0 if the address is not bound to a chain
+func (addr *AddrV2) ChainID() uint32 { return addr.chainID }
+
+// V1 returns the V1 address of the same hash
+func (addr *AddrV2) V1() *AddrV1 {
+	return &AddrV1{payload: addr.payload}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-address/address/bech32"
+)
+
+func TestAddrV2(t *testing.T) {
+	r := require.New(t)
+
+	v1, err := FromString("io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5")
+	r.NoError(err)
+	r.Equal(Version1, v1.Version())
+
+	for _, v := range []struct {
+		typ     AddrType
+		chainID uint32
+		mainnet string
+		testnet string
+	}{
+		{AccountType, 0, "io1qgqxe03thrxsqwmmhtkn9ejmmtsskfe3s4sqmv75q7", "it1qgqxe03thrxsqwmmhtkn9ejmmtsskfe3s4sqenw26j"},
+		{ContractType, 1, "io1qgqsqqqqq9ktu2ace5qrk7a6a5ewvk76uy9jwvv9vq882wsd", "it1qgqsqqqqq9ktu2ace5qrk7a6a5ewvk76uy9jwvv9vqfvkydr"},
+		{ProtocolType, 0, "io1qgpxe03thrxsqwmmhtkn9ejmmtsskfe3s4sq4uyrmu", "it1qgpxe03thrxsqwmmhtkn9ejmmtsskfe3s4sqhr5aps"},
+		{MultisigType, 2, "io1qgpsqqqqqfktu2ace5qrk7a6a5ewvk76uy9jwvv9vqcnu7ca", "it1qgpsqqqqqfktu2ace5qrk7a6a5ewvk76uy9jwvv9vqkcq59n"},
+	} {
+		addr, err := NewAddrV2(v.typ, v.chainID, v1.Bytes())
+		r.NoError(err)
+		r.Equal(Version2, addr.Version())
+		r.Equal(v.typ, addr.Type())
+		r.Equal(v.chainID, addr.ChainID())
+		r.Equal(v.mainnet, addr.String())
+		r.Equal(v.testnet, StringOn(Testnet, addr))
+		r.Equal(v1.Hex(), addr.Hex())
+
+		// FromString dispatches on the version
+		for _, s := range []string{v.mainnet, strings.ToUpper(v.mainnet)} {
+			decoded, err := FromString(s)
+			r.NoError(err)
+			r.Equal(addr, decoded)
+			decoded, err = FromStringLegacy(s)
+			r.NoError(err)
+			r.Equal(addr, decoded)
+		}
+		decoded, err := NewCodec(Testnet).FromString(v.testnet)
+		r.NoError(err)
+		r.Equal(addr, decoded)
+		_, err = FromString(v.testnet)
+		r.True(errors.Is(err, ErrInvalidAddr))
+
+		// conversion keeps the hash
+		r.True(Equal(v1, addr))
+		r.True(Equal(v1, addr.V1()))
+		converted, err := ToV1(addr)
+		r.NoError(err)
+		r.Equal(v1.String(), converted.String())
+		back, err := ToV2(v1, v.typ, v.chainID)
+		r.NoError(err)
+		r.Equal(addr, back)
+		back, err = ToV2(addr, v.typ, v.chainID)
+		r.NoError(err)
+		r.Equal(addr, back)
+		_, err = ToV2(addr, v.typ+1, v.chainID)
+		r.True(errors.Is(err, ErrInvalidAddr))
+	}
+
+	// a V2 address without chain ID can be bound to a chain, but not the other way around
+	addr, err := NewAddrV2(AccountType, 0, v1.Bytes())
+	r.NoError(err)
+	bound, err := ToV2(addr, AccountType, 1)
+	r.NoError(err)
+	r.Equal(uint32(1), bound.ChainID())
+	_, err = ToV2(bound, AccountType, 2)
+	r.True(errors.Is(err, ErrInvalidAddr))
+
+	// special addresses have no hash
+	special, err := FromString(RewardingPoolAddr)
+	r.NoError(err)
+	r.Equal(Version1, special.Version())
+	_, err = ToV2(special, ProtocolType, 0)
+	r.True(errors.Is(err, ErrNoBytes))
+	_, err = ToV1(special)
+	r.True(errors.Is(err, ErrNoBytes))
+
+	_, err = NewAddrV2(MultisigType+1, 0, v1.Bytes())
+	r.True(errors.Is(err, ErrInvalidAddr))
+	_, err = NewAddrV2(AccountType, 0, v1.Bytes()[1:])
+	r.True(errors.Is(err, ErrInvalidAddr))
+	r.Equal("multisig", MultisigType.String())
+	r.Equal("unknown(4)", AddrType(4).String())
+}
+
+func TestAddrV2Errors(t *testing.T) {
+	r := require.New(t)
+
+	var (
+		checksumErr *ChecksumError
+		lengthErr   *LengthError
+	)
+	// a bech32 checksum of V2 length is not a V2 address
+	_, err := _v2.FromStringOn(Mainnet, "io1qgqxe03thrxsqwmmhtkn9ejmmtsskfe3s4sqmv75q8")
+	r.True(errors.As(err, &checksumErr))
+	_, err = _v2.FromStringOn(Mainnet, "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5")
+	r.True(errors.As(err, &lengthErr))
+	_, err = FromString("io1qgqxe03thrxsqwmmhtkn9ejmmtsskfe3s4sqmv75q8")
+	r.True(errors.Is(err, ErrInvalidAddr))
+
+	// the payload must be canonical
+	encode := func(payload []byte) string {
+		grouped, err := bech32.ConvertBits(payload, 8, 5, true)
+		r.NoError(err)
+		s, err := bech32.EncodeM(MainnetPrefix, grouped)
+		r.NoError(err)
+		return s
+	}
+	hash := make([]byte, 20)
+	var versionErr *VersionError
+	_, err = FromString(encode(append([]byte{3, 0}, hash...)))
+	r.True(errors.As(err, &versionErr))
+	r.Equal(3, versionErr.Version)
+	_, err = FromString(encode(append([]byte{2, 0, 0, 0, 0, 0}, hash...)))
+	r.True(errors.Is(err, ErrInvalidAddr))
+	_, err = FromString(encode(append([]byte{2, 9}, hash...)))
+	r.True(errors.Is(err, ErrInvalidAddr))
+	addr, err := FromString(encode(append([]byte{2, 1}, hash...)))
+	r.NoError(err)
+	r.Equal(ContractType, addr.(*AddrV2).Type())
+
+	_, err = _v2.FromStringOn(Network{}, "io1qgqxe03thrxsqwmmhtkn9ejmmtsskfe3s4sqmv75q7")
+	r.Equal(ErrInvalidNetwork, err)
+
+	// a failed V2 decode returns a nil Address, not a nil *AddrV2, which r.Nil cannot tell apart
+	var hrpErr *HRPError
+	for _, decode := range []func(string) (Address, error){FromString, FromStringLegacy, NewCodec(Mainnet).FromString} {
+		addr, err := decode("it1qgqxe03thrxsqwmmhtkn9ejmmtsskfe3s4sqenw26j")
+		r.True(errors.As(err, &hrpErr))
+		r.Nil(addr)
+		r.True(addr == nil)
+		addr, err = decode(encode(append([]byte{3, 0}, hash...)))
+		r.True(errors.As(err, &versionErr))
+		r.Nil(addr)
+		r.True(addr == nil)
+	}
+}
@@ -27,124 +27,257 @@ import (
 
 const charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
 
-var gen = []int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+// Charset is the set of characters of the data part, in the order of the 5-bit values they encode
+const Charset = charset
+
+var gen = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+// charsetRev maps each lowercase or uppercase character of charset to its
+// index, and any other byte to -1.
+var charsetRev = func() [256]int8 {
+	var rev [256]int8
+	for i := range rev {
+		rev[i] = -1
+	}
+	for i := 0; i < len(charset); i++ {
+		c := charset[i]
+		rev[c] = int8(i)
+		if c >= 'a' && c <= 'z' {
+			rev[c-'a'+'A'] = int8(i)
+		}
+	}
+	return rev
+}()
+
+// Version is the checksum variant of a bech32 string
+type Version int
+
+const (
+	// Bech32 is the original checksum variant specified by BIP 173
+	Bech32 Version = iota
+	// Bech32m is the modified checksum variant specified by BIP 350
+	Bech32m
+)
+
+// checksum constants of the variants, xor-ed into the polymod of the data
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+// String returns the name of the variant
+func (v Version) String() string {
+	switch v {
+	case Bech32:
+		return "bech32"
+	case Bech32m:
+		return "bech32m"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(v))
+	}
+}
+
+// constant returns the checksum constant of the variant
+func (v Version) constant() uint32 {
+	if v == Bech32m {
+		return bech32mConst
+	}
+	return bech32Const
+}
 
 // Decode decodes a bech32 encoded string, returning the human-readable
 // part and the data part excluding the checksum.
 func Decode(bech string) (string, []byte, error) {
+	return decodeVersion(bech, Bech32)
+}
+
+// DecodeM decodes a bech32m encoded string, returning the human-readable
+// part and the data part excluding the checksum.
+func DecodeM(bech string) (string, []byte, error) {
+	return decodeVersion(bech, Bech32m)
+}
+
+// DecodeGeneric decodes a string encoded with either bech32 or bech32m,
+// returning the human-readable part, the data part excluding the checksum,
+// and the variant of the checksum.
+func DecodeGeneric(bech string) (string, []byte, Version, error) {
+	return AppendDecode(nil, bech)
+}
+
+// AppendDecode decodes a string encoded with either bech32 or bech32m,
+// appending the data part excluding the checksum to dst. It returns the
+// human-readable part, the extended buffer, and the variant of the checksum.
+// It does not allocate if dst has enough capacity and bech is lowercase.
+func AppendDecode(dst []byte, bech string) (string, []byte, Version, error) {
+	start := len(dst)
+	hrp, data, chk, err := decode(dst, bech)
+	if err != nil {
+		return "", dst, 0, err
+	}
+	var version Version
+	switch chk {
+	case bech32Const:
+		version = Bech32
+	case bech32mConst:
+		version = Bech32m
+	default:
+		return "", dst, 0, checksumError(bech, hrp, data[start:], Bech32)
+	}
+	// We exclude the last 6 bytes, which is the checksum.
+	return hrp, data[:len(data)-6], version, nil
+}
+
+func decodeVersion(bech string, version Version) (string, []byte, error) {
+	hrp, data, chk, err := decode(nil, bech)
+	if err != nil {
+		return "", nil, err
+	}
+	if chk != version.constant() {
+		return "", nil, checksumError(bech, hrp, data, version)
+	}
+	// We exclude the last 6 bytes, which is the checksum.
+	return hrp, data[:len(data)-6], nil
+}
+
+// decode validates the string and appends its data part including the
+// checksum to dst, returning the lowercase human-readable part, the extended
+// buffer, and the polymod of the string, which equals the checksum constant of the variant
+// if the checksum is valid.
+func decode(dst []byte, bech string) (string, []byte, uint32, error) {
 	// The maximum allowed length for a bech32 string is 90. It must also
 	// be at least 8 characters, since it needs a non-empty HRP, a
 	// separator, and a 6 character checksum.
 	if len(bech) < 8 || len(bech) > 90 {
-		return "", nil, errors.Errorf("invalid bech32 string length %d",
-			len(bech))
+		return "", nil, 0, errors.Wrapf(ErrInvalidLength, "length %d", len(bech))
 	}
-	// Only	ASCII characters between 33 and 126 are allowed.
+	// Only	ASCII characters between 33 and 126 are allowed, and they must be
+	// either all lowercase or all uppercase.
+	var hasLower, hasUpper bool
 	for i := 0; i < len(bech); i++ {
-		if bech[i] < 33 || bech[i] > 126 {
-			return "", nil, errors.Errorf("invalid character in string: '%c'", bech[i])
+		c := bech[i]
+		if c < 33 || c > 126 {
+			return "", nil, 0, &CharError{Pos: i, Char: c}
 		}
+		hasLower = hasLower || (c >= 'a' && c <= 'z')
+		hasUpper = hasUpper || (c >= 'A' && c <= 'Z')
 	}
-
-	// The characters must be either all lowercase or all uppercase.
-	lower := strings.ToLower(bech)
-	upper := strings.ToUpper(bech)
-	if bech != lower && bech != upper {
-		return "", nil, errors.New("string not all lowercase or all uppercase")
+	if hasLower && hasUpper {
+		return "", nil, 0, ErrMixedCase
 	}
 
-	bech = lower
-
 	// The string is invalid if the last '1' is non-existent, it is the
 	// first character of the string (no human-readable part) or one of the
 	// last 6 characters of the string (since checksum cannot contain '1'),
 	// or if the string is more than 90 characters in total.
 	one := strings.LastIndexByte(bech, '1')
 	if one < 1 || one+7 > len(bech) {
-		return "", nil, errors.New("invalid index of 1")
+		return "", nil, 0, ErrInvalidSeparator
 	}
 
 	// The human-readable part is everything before the last '1'.
 	hrp := bech[:one]
-	data := bech[one+1:]
+	chk := hrpPolymod(hrp)
 
 	// Each character corresponds to the byte with value of the index in
 	// 'charset'.
-	decoded, err := toBytes(data)
-	if err != nil {
-		return "", nil, errors.Errorf("failed converting data to bytes: %v", err)
-	}
-
-	if !bech32VerifyChecksum(hrp, decoded) {
-		moreInfo := ""
-		checksum := bech[len(bech)-6:]
-		expected, err := toChars(bech32Checksum(hrp,
-			decoded[:len(decoded)-6]))
-		if err == nil {
-			moreInfo = fmt.Sprintf("Expected %v, got %v.",
-				expected, checksum)
+	for i := one + 1; i < len(bech); i++ {
+		v := charsetRev[bech[i]]
+		if v < 0 {
+			return "", nil, 0, &CharError{Pos: i, Char: bech[i]}
 		}
-		return "", nil, errors.Errorf("checksum failed: %s.", moreInfo)
+		chk = polymodStep(chk, byte(v))
+		dst = append(dst, byte(v))
 	}
+	if hasUpper {
+		hrp = strings.ToLower(hrp)
+	}
+	return hrp, dst, chk, nil
+}
 
-	// We exclude the last 6 bytes, which is the checksum.
-	return hrp, decoded[:len(decoded)-6], nil
+// checksumError reports the checksum expected by the variant
+func checksumError(bech, hrp string, decoded []byte, version Version) error {
+	checksum := strings.ToLower(bech[len(bech)-6:])
+	var expected [6]byte
+	for i, v := range checksumOf(polymod(hrp, decoded[:len(decoded)-6]), version) {
+		expected[i] = charset[v]
+	}
+	return &ChecksumError{Expected: string(expected[:]), Got: checksum}
 }
 
 // Encode encodes a byte slice into a bech32 string with the
 // human-readable part hrb. Note that the bytes must each encode 5 bits
 // (base32).
 func Encode(hrp string, data []byte) (string, error) {
-	// Calculate the checksum of the data and append it at the end.
-	checksum := bech32Checksum(hrp, data)
-	combined := append(data, checksum...)
-
-	// The resulting bech32 string is the concatenation of the hrp, the
-	// separator 1, data and checksum. Everything after the separator is
-	// represented using the specified charset.
-	dataChars, err := toChars(combined)
+	b, err := AppendEncode(make([]byte, 0, len(hrp)+len(data)+7), hrp, data)
 	if err != nil {
-		return "", errors.Errorf("unable to convert data bytes to chars: %v", err)
+		return "", err
 	}
-	return hrp + "1" + dataChars, nil
+	return string(b), nil
 }
 
-// toBytes converts each character in the string 'chars' to the value of the
-// index of the corresponding character in 'charset'.
-func toBytes(chars string) ([]byte, error) {
-	decoded := make([]byte, 0, len(chars))
-	for i := 0; i < len(chars); i++ {
-		index := strings.IndexByte(charset, chars[i])
-		if index < 0 {
-			return nil, errors.Errorf("invalid character not part of charset: %v", chars[i])
-		}
-		decoded = append(decoded, byte(index))
+// EncodeM encodes a byte slice into a bech32m string with the
+// human-readable part hrb. Note that the bytes must each encode 5 bits
+// (base32).
+func EncodeM(hrp string, data []byte) (string, error) {
+	b, err := AppendEncodeM(make([]byte, 0, len(hrp)+len(data)+7), hrp, data)
+	if err != nil {
+		return "", err
 	}
-	return decoded, nil
+	return string(b), nil
 }
 
-// toChars converts the byte slice 'data' to a string where each byte in 'data'
-// encodes the index of a character in 'charset'.
-func toChars(data []byte) (string, error) {
-	result := make([]byte, 0, len(data))
+// AppendEncode appends the bech32 encoding of the data with the
+// human-readable part hrp to dst and returns the extended buffer. It does
+// not allocate if dst has enough capacity.
+func AppendEncode(dst []byte, hrp string, data []byte) ([]byte, error) {
+	return appendEncode(dst, hrp, data, Bech32)
+}
+
+// AppendEncodeM appends the bech32m encoding of the data with the
+// human-readable part hrp to dst and returns the extended buffer. It does
+// not allocate if dst has enough capacity.
+func AppendEncodeM(dst []byte, hrp string, data []byte) ([]byte, error) {
+	return appendEncode(dst, hrp, data, Bech32m)
+}
+
+func appendEncode(dst []byte, hrp string, data []byte, version Version) ([]byte, error) {
+	// The resulting bech32 string is the concatenation of the hrp, the
+	// separator 1, data and checksum. Everything after the separator is
+	// represented using the specified charset.
+	chk := hrpPolymod(hrp)
+	start := len(dst)
+	dst = append(dst, hrp...)
+	dst = append(dst, '1')
 	for _, b := range data {
 		if int(b) >= len(charset) {
-			return "", errors.Errorf("invalid data byte: %v", b)
+			return dst[:start], errors.Wrapf(ErrInvalidDataByte, "%v", b)
 		}
-		result = append(result, charset[b])
+		chk = polymodStep(chk, b)
+		dst = append(dst, charset[b])
 	}
-	return string(result), nil
+	// Calculate the checksum of the data and append it at the end.
+	for _, v := range checksumOf(chk, version) {
+		dst = append(dst, charset[v])
+	}
+	return dst, nil
 }
 
 // ConvertBits converts a byte slice where each byte is encoding fromBits bits,
 // to a byte slice where each byte is encoding toBits bits.
 func ConvertBits(data []byte, fromBits, toBits uint8, pad bool) ([]byte, error) {
+	return AppendConvertBits(nil, data, fromBits, toBits, pad)
+}
+
+// AppendConvertBits converts a byte slice where each byte is encoding
+// fromBits bits, to bytes encoding toBits bits each, and appends them to
+// dst. It does not allocate if dst has enough capacity.
+func AppendConvertBits(dst, data []byte, fromBits, toBits uint8, pad bool) ([]byte, error) {
 	if fromBits < 1 || fromBits > 8 || toBits < 1 || toBits > 8 {
-		return nil, errors.New("only bit groups between 1 and 8 allowed")
+		return nil, ErrInvalidBitGroups
 	}
 
 	// The final bytes, each byte encoding toBits bits.
-	var regrouped []byte
+	regrouped := dst
 
 	// Keep track of the next byte we create and how many bits we have
 	// added to it out of the toBits goal.
@@ -199,64 +332,65 @@ func ConvertBits(data []byte, fromBits, toBits uint8, pad bool) ([]byte, error)
 
 	// Any incomplete group must be <= 4 bits, and all zeroes.
 	if filledBits > 0 && (filledBits > 4 || nextByte != 0) {
-		return nil, errors.New("invalid incomplete group")
+		return nil, ErrInvalidPadding
 	}
 
 	return regrouped, nil
 }
 
-// For more details on the checksum calculation, please refer to BIP 173.
-func bech32Checksum(hrp string, data []byte) []byte {
-	// Convert the bytes to list of integers, as this is needed for the
-	// checksum calculation.
-	integers := make([]int, len(data))
-	for i, b := range data {
-		integers[i] = int(b)
-	}
-	values := append(bech32HrpExpand(hrp), integers...)
-	values = append(values, []int{0, 0, 0, 0, 0, 0}...)
-	polymod := bech32Polymod(values) ^ 1
-	var res []byte
-	for i := 0; i < 6; i++ {
-		res = append(res, byte((polymod>>uint(5*(5-i)))&31))
-	}
-	return res
-}
-
 // For more details on the polymod calculation, please refer to BIP 173.
-func bech32Polymod(values []int) int {
-	chk := 1
-	for _, v := range values {
-		b := chk >> 25
-		chk = (chk&0x1ffffff)<<5 ^ v
-		for i := 0; i < 5; i++ {
-			if (b>>uint(i))&1 == 1 {
-				chk ^= gen[i]
-			}
+func polymodStep(chk uint32, v byte) uint32 {
+	b := chk >> 25
+	chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+	for i := 0; i < 5; i++ {
+		if (b>>uint(i))&1 == 1 {
+			chk ^= gen[i]
 		}
 	}
 	return chk
 }
 
-// For more details on HRP expansion, please refer to BIP 173.
-func bech32HrpExpand(hrp string) []int {
-	v := make([]int, 0, len(hrp)*2+1)
+// hrpPolymod returns the polymod of the expanded lowercase HRP. For more
+// details on HRP expansion, please refer to BIP 173.
+func hrpPolymod(hrp string) uint32 {
+	chk := uint32(1)
 	for i := 0; i < len(hrp); i++ {
-		v = append(v, int(hrp[i]>>5))
+		chk = polymodStep(chk, toLower(hrp[i])>>5)
 	}
-	v = append(v, 0)
+	chk = polymodStep(chk, 0)
 	for i := 0; i < len(hrp); i++ {
-		v = append(v, int(hrp[i]&31))
+		chk = polymodStep(chk, toLower(hrp[i])&31)
 	}
-	return v
+	return chk
+}
+
+// polymod returns the polymod of the expanded HRP followed by the data.
+func polymod(hrp string, data []byte) uint32 {
+	chk := hrpPolymod(hrp)
+	for _, b := range data {
+		chk = polymodStep(chk, b)
+	}
+	return chk
+}
+
+// checksumOf returns the 6 checksum values given the polymod of the HRP and
+// data. For more details on the checksum calculation, please refer to BIP 173
+// and BIP 350.
+func checksumOf(chk uint32, version Version) [6]byte {
+	for i := 0; i < 6; i++ {
+		chk = polymodStep(chk, 0)
+	}
+	chk ^= version.constant()
+	var res [6]byte
+	for i := 0; i < 6; i++ {
+		res[i] = byte((chk >> uint(5*(5-i))) & 31)
+	}
+	return res
 }
 
-// For more details on the checksum verification, please refer to BIP 173.
-func bech32VerifyChecksum(hrp string, data []byte) bool {
-	integers := make([]int, len(data))
-	for i, b := range data {
-		integers[i] = int(b)
+func toLower(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
 	}
-	concat := append(bech32HrpExpand(hrp), integers...)
-	return bech32Polymod(concat) == 1
+	return c
 }
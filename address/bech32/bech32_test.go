@@ -7,6 +7,10 @@
 package bech32
 
 import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
 )
@@ -69,3 +73,292 @@ func TestBech32(t *testing.T) {
 		}
 	}
 }
+
+func TestBech32m(t *testing.T) {
+	tests := []struct {
+		str   string
+		valid bool
+	}{
+		// Try some test vectors from https://github.com/bitcoin/bips/blob/master/bip-0350.mediawiki#Test_vectors_for_Bech32m
+		{"A1LQFN3A", true},
+		{"a1lqfn3a", true},
+		{"an83characterlonghumanreadablepartthatcontainsthetheexcludedcharactersbioandnumber11sg7hg6", true},
+		{"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx", true},
+		{"11llllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllludsr8", true},
+		{"split1checkupstagehandshakeupstreamerranterredcaperredlc445v", true},
+		{"?1v759aa", true},
+		{string([]byte{0x20}) + "1xj0phk", false}, // HRP character out of range
+		{string([]byte{0x7f}) + "1g6xzxy", false}, // HRP character out of range
+		{string([]byte{0x80}) + "1vctc34", false}, // HRP character out of range
+		{"an84characterslonghumanreadablepartthatcontainsthetheexcludedcharactersbioandnumber11d6pts4", false}, // overall max length exceeded
+		{"qyrz8wqd2c9m", false},  // no separator character
+		{"1qyrz8wqd2c9m", false}, // empty HRP
+		{"y1b0jsk6g", false},     // invalid data character
+		{"lt1igcx5c0", false},    // invalid data character
+		{"in1muywd", false},      // too short checksum
+		{"mm1crxm3i", false},     // invalid character in checksum
+		{"au1s5cgom", false},     // invalid character in checksum
+		{"M1VUXWEZ", false},      // checksum calculated with uppercase form of HRP
+		{"16plkw9", false},       // empty HRP
+		{"1p2gdwpf", false},      // empty HRP
+		{"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", false}, // valid bech32, not bech32m
+	}
+
+	for _, test := range tests {
+		str := test.str
+		hrp, decoded, err := DecodeM(str)
+		if !test.valid {
+			// Invalid string decoding should result in error.
+			if err == nil {
+				t.Errorf("expected decoding to fail for invalid string %v", test.str)
+			}
+			continue
+		}
+
+		// Valid string decoding should result in no error.
+		if err != nil {
+			t.Errorf("expected string to be valid bech32m: %v", err)
+		}
+
+		// A bech32m string is not a valid bech32 string
+		if _, _, err = Decode(str); err == nil {
+			t.Errorf("expected bech32 decoding to fail for %v", str)
+		}
+
+		// Check that it encodes to the same string
+		encoded, err := EncodeM(hrp, decoded)
+		if err != nil {
+			t.Errorf("encoding failed: %v", err)
+		}
+
+		if encoded != strings.ToLower(str) {
+			t.Errorf("expected data to encode to %v, but got %v", str, encoded)
+		}
+
+		// Flip a bit in the string an make sure it is caught.
+		pos := strings.LastIndexAny(str, "1")
+		flipped := str[:pos+1] + string((str[pos+1] ^ 1)) + str[pos+2:]
+		_, _, err = DecodeM(flipped)
+		if err == nil {
+			t.Error("expected decoding to fail")
+		}
+	}
+}
+
+func TestDecodeGeneric(t *testing.T) {
+	tests := []struct {
+		str     string
+		version Version
+		valid   bool
+	}{
+		{"a12uel5l", Bech32, true},
+		{"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", Bech32, true},
+		{"a1lqfn3a", Bech32m, true},
+		{"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx", Bech32m, true},
+		{"split1checkupstagehandshakeupstreamerranterredcaperred2y9e2w", Bech32, false},
+	}
+
+	for _, test := range tests {
+		hrp, decoded, version, err := DecodeGeneric(test.str)
+		if !test.valid {
+			if err == nil {
+				t.Errorf("expected decoding to fail for invalid string %v", test.str)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("expected string to be valid: %v", err)
+			continue
+		}
+		if version != test.version {
+			t.Errorf("expected %v variant for %v, but got %v", test.version, test.str, version)
+		}
+		encode := Encode
+		if version == Bech32m {
+			encode = EncodeM
+		}
+		encoded, err := encode(hrp, decoded)
+		if err != nil {
+			t.Errorf("encoding failed: %v", err)
+		}
+		if encoded != test.str {
+			t.Errorf("expected data to encode to %v, but got %v", test.str, encoded)
+		}
+	}
+}
+
+func TestLocateErrors(t *testing.T) {
+	const valid = "split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w"
+	positions, err := LocateErrors(valid, Bech32)
+	if err != nil || positions != nil {
+		t.Errorf("expected no errors in %v, but got %v, %v", valid, positions, err)
+	}
+
+	rnd := rand.New(rand.NewSource(0))
+	for i := 0; i < 200; i++ {
+		// substitute one or two random characters of the data part
+		b := []byte(valid)
+		var expected []int
+		for len(expected) < 1+i%2 {
+			pos := 6 + rnd.Intn(len(valid)-6)
+			if len(expected) == 1 && expected[0] == pos {
+				continue
+			}
+			c := charset[rnd.Intn(len(charset))]
+			if c == b[pos] {
+				continue
+			}
+			b[pos] = c
+			expected = append(expected, pos)
+		}
+		sort.Ints(expected)
+		str := string(b)
+		positions, err := LocateErrors(str, Bech32)
+		if err != nil {
+			t.Errorf("failed to locate errors in %v: %v", str, err)
+			continue
+		}
+		if !reflect.DeepEqual(expected, positions) {
+			t.Errorf("expected errors at %v in %v, but got %v", expected, str, positions)
+		}
+		corrections, err := Corrections(str, Bech32)
+		if err != nil || len(corrections) != 1 || corrections[0] != valid {
+			t.Errorf("expected %v to be corrected to %v, but got %v, %v", str, valid, corrections, err)
+		}
+		// the same string is not a corrupted bech32m string
+		if corrections, _ = Corrections(str, Bech32m); len(corrections) != 0 {
+			t.Errorf("expected no bech32m corrections for %v, but got %v", str, corrections)
+		}
+	}
+
+	// characters outside of the charset are located as well
+	positions, err = LocateErrors("split1checkupstagehandshakeupstreamerrantorredcaperred2y9e3b", Bech32)
+	if err != nil || !reflect.DeepEqual([]int{41, 59}, positions) {
+		t.Errorf("expected errors at [41 59], but got %v, %v", positions, err)
+	}
+	if _, err = LocateErrors("split1checkupstagehandshakeupstreamerranterredcaperred2y9ebb", Bech32); err != nil {
+		t.Errorf("failed to locate 2 invalid characters: %v", err)
+	}
+	if _, err = LocateErrors("split1checkupstagehandshakeupstreamerranterredcaperred2y9bbb", Bech32); err == nil {
+		t.Error("expected 3 invalid characters to fail")
+	}
+}
+
+func TestAppendNoAlloc(t *testing.T) {
+	payload := make([]byte, 20)
+	for i := range payload {
+		payload[i] = byte(i * 13)
+	}
+	grouped := make([]byte, 0, 32)
+	encoded := make([]byte, 0, 90)
+	decoded := make([]byte, 0, 90)
+	allocs := testing.AllocsPerRun(100, func() {
+		g, err := AppendConvertBits(grouped[:0], payload, 8, 5, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e, err := AppendEncode(encoded[:0], "io", g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, d, version, err := AppendDecode(decoded[:0], string(e))
+		if err != nil || version != Bech32 || len(d) != 32 {
+			t.Fatalf("failed to decode %s: %v", e, err)
+		}
+	})
+	// the conversion of the encoded bytes into a string for decoding is the only allocation
+	if allocs > 1 {
+		t.Errorf("expected at most 1 allocation, got %v", allocs)
+	}
+
+	// append to a non-empty buffer
+	e, err := AppendEncode([]byte("addr: "), "a", nil)
+	if err != nil || string(e) != "addr: a12uel5l" {
+		t.Errorf("expected addr: a12uel5l, got %s, %v", e, err)
+	}
+	_, d, _, err := AppendDecode([]byte{1, 2}, "A12UEL5L")
+	if err != nil || len(d) != 2 {
+		t.Errorf("expected the prefix to be kept, got %v, %v", d, err)
+	}
+	if _, err = AppendEncode(nil, "a", []byte{32}); err == nil {
+		t.Error("expected encoding invalid data byte to fail")
+	}
+}
+
+func BenchmarkAppendEncode(b *testing.B) {
+	grouped, err := ConvertBits(make([]byte, 20), 8, 5, true)
+	if err != nil {
+		b.Fatal(err)
+	}
+	buf := make([]byte, 0, 90)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := AppendEncode(buf[:0], "io", grouped); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAppendDecode(b *testing.B) {
+	const addr = "io1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqd39ym7"
+	buf := make([]byte, 0, 90)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, _, err := AppendDecode(buf[:0], addr); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncode(b *testing.B) {
+	grouped, err := ConvertBits(make([]byte, 20), 8, 5, true)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Encode("io", grouped); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, test := range []struct {
+		str string
+		err error
+	}{
+		{"a1", ErrInvalidLength},
+		{"a12uel5L", ErrMixedCase},
+		{"a2uel5lxx", ErrInvalidSeparator},
+		{"a12uel5b", ErrInvalidCharacter},
+		{"a12uel5m", ErrInvalidChecksum},
+	} {
+		if _, _, err := Decode(test.str); !errors.Is(err, test.err) {
+			t.Errorf("expected %v for %v, but got %v", test.err, test.str, err)
+		}
+	}
+
+	_, _, err := Decode("a12uel5b")
+	var charErr *CharError
+	if !errors.As(err, &charErr) || charErr.Pos != 7 || charErr.Char != 'b' {
+		t.Errorf("expected invalid character b at position 7, but got %v", err)
+	}
+	_, _, err = Decode("a12uel5m")
+	var checksumErr *ChecksumError
+	if !errors.As(err, &checksumErr) || checksumErr.Expected != "2uel5l" {
+		t.Errorf("expected checksum 2uel5l, but got %v", err)
+	}
+	if _, err = ConvertBits([]byte{1}, 0, 8, false); !errors.Is(err, ErrInvalidBitGroups) {
+		t.Errorf("expected %v, but got %v", ErrInvalidBitGroups, err)
+	}
+	if _, err = ConvertBits([]byte{1}, 5, 8, false); !errors.Is(err, ErrInvalidPadding) {
+		t.Errorf("expected %v, but got %v", ErrInvalidPadding, err)
+	}
+	if _, err = Encode("a", []byte{32}); !errors.Is(err, ErrInvalidDataByte) {
+		t.Errorf("expected %v, but got %v", ErrInvalidDataByte, err)
+	}
+}
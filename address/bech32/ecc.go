@@ -0,0 +1,187 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package bech32
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// The checksum is a BCH code with a minimum distance of 5 over strings of up to 90 characters, so any
+// combination of e erasures (characters known to be wrong) and t substitutions with e + 2t < 5 can be
+// corrected uniquely. The error correction below is limited to MaxCorrections characters in total.
+//
+// The polymod is affine in the data values: substituting the value v at offset k from the end of the
+// data part with v^e changes the polymod by a residue that depends only on (k, e). Substitutions are
+// located by matching the residue of the string against the residues of single and double substitutions.
+
+// MaxCorrections is the maximum number of characters that can be corrected
+const MaxCorrections = 2
+
+// maxDataLength is the maximum length of the data part, including the checksum
+const maxDataLength = 88
+
+type substitution struct {
+	offset int  // offset from the end of the data part
+	value  byte // value xor-ed into the character
+}
+
+var (
+	residuesOnce sync.Once
+	// residues[k][e] is the polymod change of xor-ing e into the character at offset k from the end
+	residues [maxDataLength][32]uint32
+	// singles maps the polymod change to the single substitution causing it
+	singles map[uint32]substitution
+)
+
+func initResidues() {
+	residuesOnce.Do(func() {
+		singles = make(map[uint32]substitution, maxDataLength*31)
+		for e := 1; e < 32; e++ {
+			chk := uint32(e)
+			for k := 0; k < maxDataLength; k++ {
+				if k > 0 {
+					chk = polymodStep(chk, 0)
+				}
+				residues[k][e] = chk
+				singles[chk] = substitution{offset: k, value: byte(e)}
+			}
+		}
+	})
+}
+
+// LocateErrors returns the positions in bech of the characters that are likely wrong, if the string has
+// an invalid checksum for the variant. Up to MaxCorrections substituted characters in the data part can be
+// located. A nil slice is returned for a valid string.
+func LocateErrors(bech string, version Version) ([]int, error) {
+	corrections, positions, err := correct(bech, version)
+	if err != nil {
+		return nil, err
+	}
+	switch len(corrections) {
+	case 0:
+		return nil, errors.Errorf("more than %d errors in string", MaxCorrections)
+	case 1:
+		return positions[0], nil
+	default:
+		return nil, errors.New("errors cannot be located unambiguously")
+	}
+}
+
+// Corrections returns the strings valid for the variant that differ from bech in at most MaxCorrections
+// characters of the data part. The corrected strings are lowercase, and bech itself is returned if valid.
+func Corrections(bech string, version Version) ([]string, error) {
+	corrections, _, err := correct(bech, version)
+	return corrections, err
+}
+
+// correct returns the corrected strings and the positions of the corrected characters in each
+func correct(bech string, version Version) ([]string, [][]int, error) {
+	if len(bech) < 8 || len(bech) > 90 {
+		return nil, nil, errors.Wrapf(ErrInvalidLength, "length %d", len(bech))
+	}
+	// Case is not significant for correction
+	bech = strings.ToLower(bech)
+	one := strings.LastIndexByte(bech, '1')
+	if one < 1 || one+7 > len(bech) {
+		return nil, nil, ErrInvalidSeparator
+	}
+	hrp := bech[:one]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return nil, nil, &CharError{Pos: i, Char: hrp[i]}
+		}
+	}
+	// Characters that are not part of the charset are erasures, whose position is known
+	data := make([]byte, len(bech)-one-1)
+	var erasures []int
+	for i := range data {
+		index := charsetRev[bech[one+1+i]]
+		if index < 0 {
+			erasures = append(erasures, i)
+			continue
+		}
+		data[i] = byte(index)
+	}
+	if len(erasures) > MaxCorrections {
+		return nil, nil, errors.Errorf("more than %d invalid characters in string", MaxCorrections)
+	}
+
+	initResidues()
+	var (
+		n         = len(data)
+		budget    = MaxCorrections - len(erasures)
+		found     = map[string]bool{}
+		corrected []string
+		positions [][]int
+	)
+	add := func(fixes ...substitution) {
+		fixed := make([]byte, n)
+		copy(fixed, data)
+		var pos []int
+		for _, f := range fixes {
+			fixed[n-1-f.offset] ^= f.value
+			pos = append(pos, one+1+n-1-f.offset)
+		}
+		s := hrp + "1"
+		for _, b := range fixed {
+			s += string(charset[b])
+		}
+		if found[s] {
+			return
+		}
+		found[s] = true
+		sort.Ints(pos)
+		corrected = append(corrected, s)
+		positions = append(positions, pos)
+	}
+	// try every value for the erasures, then locate the remaining substitutions
+	fills := 1 << (5 * uint(len(erasures)))
+	for fill := 0; fill < fills; fill++ {
+		var fixes []substitution
+		for i, e := range erasures {
+			data[e] = byte(fill>>(5*uint(i))) & 31
+			// erasures are reported as substitutions of the filled value
+			fixes = append(fixes, substitution{offset: n - 1 - e})
+		}
+		residue := polymod(hrp, data) ^ version.constant()
+		if residue == 0 {
+			add(fixes...)
+			continue
+		}
+		if budget >= 1 {
+			if s, ok := singles[residue]; ok && s.offset < n && !isErasure(erasures, n-1-s.offset) {
+				add(append(fixes, s)...)
+				continue
+			}
+		}
+		if budget >= 2 {
+			for k := 0; k < n; k++ {
+				for e := 1; e < 32; e++ {
+					s, ok := singles[residue^residues[k][e]]
+					if !ok || s.offset <= k || s.offset >= n {
+						continue
+					}
+					add(append(fixes, substitution{offset: k, value: byte(e)}, s)...)
+				}
+			}
+		}
+	}
+	return corrected, positions, nil
+}
+
+func isErasure(erasures []int, i int) bool {
+	for _, e := range erasures {
+		if e == i {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package bech32
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Sentinel errors returned by the package, to be checked with errors.Is
+var (
+	// ErrInvalidLength indicates the string is shorter than 8 or longer than 90 characters
+	ErrInvalidLength = errors.New("invalid bech32 string length")
+	// ErrInvalidCharacter indicates a character out of range, or not part of the charset in the data part
+	ErrInvalidCharacter = errors.New("invalid character")
+	// ErrMixedCase indicates the string mixes lowercase and uppercase characters
+	ErrMixedCase = errors.New("string not all lowercase or all uppercase")
+	// ErrInvalidSeparator indicates the separator "1" is missing or misplaced
+	ErrInvalidSeparator = errors.New("invalid index of 1")
+	// ErrInvalidChecksum indicates the checksum does not match
+	ErrInvalidChecksum = errors.New("checksum failed")
+	// ErrInvalidDataByte indicates a data byte that does not encode 5 bits
+	ErrInvalidDataByte = errors.New("invalid data byte")
+	// ErrInvalidBitGroups indicates bit groups other than 1 to 8 bits
+	ErrInvalidBitGroups = errors.New("only bit groups between 1 and 8 allowed")
+	// ErrInvalidPadding indicates an incomplete group of more than 4 bits, or of non-zero bits
+	ErrInvalidPadding = errors.New("invalid incomplete group")
+)
+
+// CharError reports an invalid character and its position in the string
+type CharError struct {
+	Pos  int
+	Char byte
+}
+
+// Error returns the error message
+func (e *CharError) Error() string {
+	return fmt.Sprintf("invalid character in string: '%c' at position %d", e.Char, e.Pos)
+}
+
+// Is returns true for ErrInvalidCharacter
+func (e *CharError) Is(target error) bool { return target == ErrInvalidCharacter }
+
+// ChecksumError reports the checksum expected by the variant and the one in the string
+type ChecksumError struct {
+	Expected string
+	Got      string
+}
+
+// Error returns the error message
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("checksum failed: Expected %s, got %s..", e.Expected, e.Got)
+}
+
+// Is returns true for ErrInvalidChecksum
+func (e *ChecksumError) Is(target error) bool { return target == ErrInvalidChecksum }
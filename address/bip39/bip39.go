@@ -0,0 +1,192 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package bip39 implements BIP-39 mnemonic sentences of the English wordlist, and the seeds derived from them
+//
+// The mnemonic and passphrase are used as given, rather than normalized to NFKD. The English words are ASCII, but a
+// non-ASCII passphrase must be normalized by the caller to derive the same seed as other implementations.
+package bip39
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// MinEntropyBits is the bit length of the entropy of a 12-word mnemonic
+	MinEntropyBits = 128
+	// MaxEntropyBits is the bit length of the entropy of a 24-word mnemonic
+	MaxEntropyBits = 256
+	// SeedLength is the byte length of a seed
+	SeedLength = 64
+	// seedIterations is the number of PBKDF2 iterations
+	seedIterations = 2048
+	// bitsPerWord is the number of bits a word encodes
+	bitsPerWord = 11
+)
+
+var (
+	// ErrInvalidEntropy indicates an entropy of invalid length
+	ErrInvalidEntropy = errors.New("invalid entropy")
+	// ErrInvalidMnemonic indicates an invalid mnemonic, which every mnemonic error wraps
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+)
+
+type (
+	// WordError reports a word not in the wordlist, Pos being its 0-based position in the mnemonic
+	WordError struct {
+		Pos  int
+		Word string
+	}
+
+	// ChecksumError reports a mnemonic whose checksum does not match its entropy
+	ChecksumError struct{}
+)
+
+// Error returns the error message
+func (e *WordError) Error() string {
+	return fmt.Sprintf("word %q at position %d is not in the wordlist: %v", e.Word, e.Pos, ErrInvalidMnemonic)
+}
+
+// Unwrap returns ErrInvalidMnemonic
+func (e *WordError) Unwrap() error { return ErrInvalidMnemonic }
+
+// Error returns the error message
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("checksum failed: %v", ErrInvalidMnemonic)
+}
+
+// Unwrap returns ErrInvalidMnemonic
+func (e *ChecksumError) Unwrap() error { return ErrInvalidMnemonic }
+
+// wordIndex maps each word to its index in the wordlist
+var wordIndex = func() map[string]int {
+	m := make(map[string]int, len(english))
+	for i, w := range english {
+		m[w] = i
+	}
+	return m
+}()
+
+// NewEntropy returns random entropy of the bit length, which is a multiple of 32 from 128 to 256
+func NewEntropy(bits int) ([]byte, error) {
+	if err := checkEntropyBits(bits); err != nil {
+		return nil, err
+	}
+	entropy := make([]byte, bits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, errors.Wrap(err, "failed to read random bytes")
+	}
+	return entropy, nil
+}
+
+// NewMnemonic encodes the entropy into a mnemonic of 12, 15, 18, 21 or 24 words
+func NewMnemonic(entropy []byte) (string, error) {
+	bits := len(entropy) * 8
+	if err := checkEntropyBits(bits); err != nil {
+		return "", err
+	}
+	// the entropy is followed by the first bits/32 bits of its SHA-256 hash
+	hash := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), hash[0])
+	words := make([]string, 0, (bits+bits/32)/bitsPerWord)
+	for i := 0; i < cap(words); i++ {
+		words = append(words, english[bitsAt(data, i*bitsPerWord)])
+	}
+	return strings.Join(words, " "), nil
+}
+
+// Generate returns a random mnemonic of the entropy bit length
+func Generate(bits int) (string, error) {
+	entropy, err := NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+	return NewMnemonic(entropy)
+}
+
+// EntropyFromMnemonic decodes the mnemonic into its entropy, verifying the words and checksum
+// Words are separated by whitespace, and are case-insensitive
+func EntropyFromMnemonic(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return nil, errors.Wrapf(ErrInvalidMnemonic, "%d words, expecting 12, 15, 18, 21 or 24", len(words))
+	}
+	total := len(words) * bitsPerWord
+	checksumBits := total / 33
+	data := make([]byte, (total+7)/8)
+	for i, w := range words {
+		index, ok := wordIndex[strings.ToLower(w)]
+		if !ok {
+			return nil, &WordError{Pos: i, Word: w}
+		}
+		setBits(data, i*bitsPerWord, index)
+	}
+	entropy := data[:(total-checksumBits)/8]
+	hash := sha256.Sum256(entropy)
+	mask := byte(0xff) << uint(8-checksumBits)
+	if data[len(entropy)]&mask != hash[0]&mask {
+		return nil, &ChecksumError{}
+	}
+	return append([]byte{}, entropy...), nil
+}
+
+// Validate returns nil for a valid mnemonic
+func Validate(mnemonic string) error {
+	_, err := EntropyFromMnemonic(mnemonic)
+	return err
+}
+
+// NewSeed derives the 64-byte seed from the mnemonic and passphrase without validating the mnemonic, as specified by
+// BIP-39, use NewSeedWithChecksum to reject an invalid mnemonic
+func NewSeed(mnemonic, passphrase string) []byte {
+	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"+passphrase), seedIterations, SeedLength, sha512.New)
+}
+
+// NewSeedWithChecksum validates the mnemonic, and derives the 64-byte seed from it and the passphrase
+// The words are joined by a single space in lowercase, so that a mnemonic typed with extra whitespace or capitals
+// derives the same seed
+func NewSeedWithChecksum(mnemonic, passphrase string) ([]byte, error) {
+	if err := Validate(mnemonic); err != nil {
+		return nil, err
+	}
+	return NewSeed(strings.ToLower(strings.Join(strings.Fields(mnemonic), " ")), passphrase), nil
+}
+
+func checkEntropyBits(bits int) error {
+	if bits < MinEntropyBits || bits > MaxEntropyBits || bits%32 != 0 {
+		return errors.Wrapf(ErrInvalidEntropy, "entropy of %d bits, expecting a multiple of 32 from %d to %d", bits,
+			MinEntropyBits, MaxEntropyBits)
+	}
+	return nil
+}
+
+// bitsAt returns the 11 bits of data starting at the bit offset
+func bitsAt(data []byte, offset int) int {
+	v := 0
+	for i := offset; i < offset+bitsPerWord; i++ {
+		v = v<<1 | int(data[i/8]>>uint(7-i%8)&1)
+	}
+	return v
+}
+
+// setBits sets the 11 bits of data starting at the bit offset to v
+func setBits(data []byte, offset, v int) {
+	for i := 0; i < bitsPerWord; i++ {
+		if v>>uint(bitsPerWord-1-i)&1 == 1 {
+			pos := offset + i
+			data[pos/8] |= 1 << uint(7-pos%8)
+		}
+	}
+}
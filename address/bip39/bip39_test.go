@@ -0,0 +1,139 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package bip39
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordlist(t *testing.T) {
+	r := require.New(t)
+
+	r.Len(english, 1<<bitsPerWord)
+	r.Len(wordIndex, len(english))
+	// the hash of the wordlist file published with BIP-39
+	hash := sha256.Sum256([]byte(strings.Join(english, "\n") + "\n"))
+	r.Equal("2f5eed53a4727b4bf8880d8f3f199efc90e58503646d9ff8eff3a2ed3b24dbda", hex.EncodeToString(hash[:]))
+}
+
+// vectors of https://github.com/trezor/python-mnemonic/blob/master/vectors.json, with passphrase "TREZOR"
+var vectors = []struct {
+	entropy, mnemonic, seed string
+}{
+	{
+		"00000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	},
+	{
+		"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		"legal winner thank year wave sausage worth useful legal winner thank yellow",
+		"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	},
+	{
+		"80808080808080808080808080808080",
+		"letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+		"d71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f985ec81778c1b370b652a8",
+	},
+	{
+		"ffffffffffffffffffffffffffffffff",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+		"ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+	},
+	{
+		"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		"legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal will",
+		"f2b94508732bcbacbcc020faefecfc89feafa6649a5491b8c952cede496c214a0c7b3c392d168748f2d4a612bada0753b52a1c7ac53c1e93abd5c6320b9e95dd",
+	},
+	{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		"bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+	},
+	{
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+		"dd48c104698c30cfe2b6142103248622fb7bb0ff692eebb00089b32d22484e1613912f0a5b694407be899ffd31ed3992c456cdf60f5d4564b8ba3f05a69890ad",
+	},
+}
+
+func TestMnemonic(t *testing.T) {
+	r := require.New(t)
+
+	for _, v := range vectors {
+		entropy, err := hex.DecodeString(v.entropy)
+		r.NoError(err)
+		mnemonic, err := NewMnemonic(entropy)
+		r.NoError(err)
+		r.Equal(v.mnemonic, mnemonic)
+		decoded, err := EntropyFromMnemonic(mnemonic)
+		r.NoError(err)
+		r.Equal(entropy, decoded)
+		r.Equal(v.seed, hex.EncodeToString(NewSeed(mnemonic, "TREZOR")))
+		seed, err := NewSeedWithChecksum(mnemonic, "TREZOR")
+		r.NoError(err)
+		r.Equal(v.seed, hex.EncodeToString(seed))
+	}
+
+	// whitespace and case do not matter to a validated mnemonic
+	seed, err := NewSeedWithChecksum("  Abandon abandon\tabandon abandon abandon abandon abandon abandon abandon abandon\n"+
+		"abandon ABOUT ", "TREZOR")
+	r.NoError(err)
+	r.Equal(vectors[0].seed, hex.EncodeToString(seed))
+
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		mnemonic, err := Generate(bits)
+		r.NoError(err)
+		r.Len(strings.Fields(mnemonic), bits*33/32/bitsPerWord)
+		r.NoError(Validate(mnemonic))
+	}
+	for _, bits := range []int{0, 96, 127, 136, 288} {
+		_, err := NewEntropy(bits)
+		r.True(errors.Is(err, ErrInvalidEntropy))
+		_, err = NewMnemonic(make([]byte, bits/8))
+		r.True(errors.Is(err, ErrInvalidEntropy))
+	}
+}
+
+func TestInvalidMnemonic(t *testing.T) {
+	r := require.New(t)
+
+	for _, v := range []string{
+		"",
+		"abandon",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	} {
+		r.True(errors.Is(Validate(v), ErrInvalidMnemonic))
+	}
+
+	err := Validate("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandonn")
+	var wordErr *WordError
+	r.True(errors.As(err, &wordErr))
+	r.Equal(11, wordErr.Pos)
+	r.Equal("abandonn", wordErr.Word)
+	r.True(errors.Is(err, ErrInvalidMnemonic))
+
+	for _, v := range []string{
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"legal winner thank year wave sausage worth useful legal winner thank year",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo",
+	} {
+		err := Validate(v)
+		var checksumErr *ChecksumError
+		r.True(errors.As(err, &checksumErr))
+		r.True(errors.Is(err, ErrInvalidMnemonic))
+		_, err = NewSeedWithChecksum(v, "")
+		r.True(errors.Is(err, ErrInvalidMnemonic))
+	}
+}
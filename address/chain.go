@@ -0,0 +1,120 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// An address is bound to a chain by the chain ID of its V2 form, e.g., io1qgqsqqqqq9... is bound to chain 1. V1
+// addresses and V2 addresses without chain ID are unbound, and could be used on any chain. The APIs below reject an
+// address bound to another chain with ChainError, so that sending to it is a detectable error.
+
+// chain IDs of IoTeX blockchain
+const (
+	// MainnetChainID is the chain ID of mainnet
+	MainnetChainID uint32 = 1
+	// TestnetChainID is the chain ID of testnet
+	TestnetChainID uint32 = 2
+)
+
+// ErrWrongChain indicates an address bound to another chain
+var ErrWrongChain = errors.New("address of another chain")
+
+// ChainError reports an address bound to a chain other than the expected one, Got is 0 for an unbound address
+type ChainError struct {
+	Got  uint32
+	Want uint32
+}
+
+// Error returns the error message
+func (e *ChainError) Error() string {
+	if e.Got == 0 {
+		return fmt.Sprintf("address not bound to chain %d: %v", e.Want, ErrInvalidAddr)
+	}
+	return fmt.Sprintf("address of chain %d, expecting chain %d: %v", e.Got, e.Want, ErrInvalidAddr)
+}
+
+// Unwrap returns ErrInvalidAddr
+func (e *ChainError) Unwrap() error { return ErrInvalidAddr }
+
+// Cause returns ErrInvalidAddr, for errors.Cause of github.com/pkg/errors
+func (e *ChainError) Cause() error { return ErrInvalidAddr }
+
+// Is returns true for ErrWrongChain
+func (e *ChainError) Is(target error) bool { return target == ErrWrongChain }
+
+// ChainOf returns the chain ID the address is bound to, or 0 if it is unbound
+func ChainOf(addr Address) uint32 {
+	if a, ok := addr.(*AddrV2); ok {
+		return a.ChainID()
+	}
+	return 0
+}
+
+// CheckChain returns nil if the address is bound to the chain, or unbound and allowUnbound is true
+func CheckChain(chainID uint32, addr Address, allowUnbound bool) error {
+	switch got := ChainOf(addr); {
+	case got == chainID:
+		return nil
+	case got == 0 && allowUnbound:
+		return nil
+	default:
+		return &ChainError{Got: got, Want: chainID}
+	}
+}
+
+// BindChain binds the address to the chain, keeping the type of a V2 address, and a V1 address becomes an account
+// An address already bound to another chain returns ChainError
+func BindChain(chainID uint32, addr Address) (*AddrV2, error) {
+	if chainID == 0 {
+		return nil, errors.Wrap(ErrInvalidAddr, "chain ID 0 cannot be bound")
+	}
+	if err := CheckChain(chainID, addr, true); err != nil {
+		return nil, err
+	}
+	t := AccountType
+	if a, ok := addr.(*AddrV2); ok {
+		t = a.Type()
+	}
+	return ToV2(addr, t, chainID)
+}
+
+// FromStringOnChain decodes an address string bound to the chain on the default network
+// An unbound address, or an address bound to another chain, returns ChainError
+func FromStringOnChain(chainID uint32, encodedAddr string) (*AddrV2, error) {
+	return fromStringOnChain(DefaultNetwork(), chainID, encodedAddr)
+}
+
+func fromStringOnChain(net Network, chainID uint32, encodedAddr string) (*AddrV2, error) {
+	addr, err := FromStringOn(net, encodedAddr)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckChain(chainID, addr, false); err != nil {
+		return nil, err
+	}
+	return addr.(*AddrV2), nil
+}
+
+// StringOnChain encodes the address bound to the chain into a string on the default network
+// An address bound to another chain returns ChainError
+func StringOnChain(chainID uint32, addr Address) (string, error) {
+	a, err := BindChain(chainID, addr)
+	if err != nil {
+		return "", err
+	}
+	return a.String(), nil
+}
+
+// EqualOnChain returns true if the addresses are equal and bound to the same chain
+// Unlike Equal, a V1 address is not equal to its form bound to a chain
+func EqualOnChain(addr1 Address, addr2 Address) bool {
+	return Equal(addr1, addr2) && ChainOf(addr1) == ChainOf(addr2)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+	r := require.New(t)
+
+	const (
+		v1Addr     = "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5"
+		chain1Addr = "io1qgqsqqqqq9ktu2ace5qrk7a6a5ewvk76uy9jwvv9vq882wsd" // contract on chain 1
+		chain2Addr = "io1qgpsqqqqqfktu2ace5qrk7a6a5ewvk76uy9jwvv9vqcnu7ca" // multisig on chain 2
+	)
+	v1, err := FromString(v1Addr)
+	r.NoError(err)
+	r.Zero(ChainOf(v1))
+	on1, err := FromStringOnChain(MainnetChainID, chain1Addr)
+	r.NoError(err)
+	r.Equal(MainnetChainID, ChainOf(on1))
+	r.Equal(ContractType, on1.Type())
+
+	// wrong-chain and unbound addresses are detected
+	var chainErr *ChainError
+	_, err = FromStringOnChain(MainnetChainID, chain2Addr)
+	r.True(errors.As(err, &chainErr))
+	r.Equal(ChainError{Got: TestnetChainID, Want: MainnetChainID}, *chainErr)
+	r.True(errors.Is(err, ErrWrongChain))
+	r.True(errors.Is(err, ErrInvalidAddr))
+	r.Equal("address of chain 2, expecting chain 1: invalid address", err.Error())
+	_, err = FromStringOnChain(MainnetChainID, v1Addr)
+	r.True(errors.As(err, &chainErr))
+	r.Zero(chainErr.Got)
+	r.Equal("address not bound to chain 1: invalid address", err.Error())
+	_, err = FromStringOnChain(MainnetChainID, "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h4")
+	r.True(errors.Is(err, ErrInvalidAddr))
+	r.False(errors.Is(err, ErrWrongChain))
+
+	r.NoError(CheckChain(MainnetChainID, on1, false))
+	r.NoError(CheckChain(MainnetChainID, v1, true))
+	r.True(errors.Is(CheckChain(MainnetChainID, v1, false), ErrWrongChain))
+	r.True(errors.Is(CheckChain(TestnetChainID, on1, true), ErrWrongChain))
+
+	// binding keeps the hash and the type
+	bound, err := BindChain(MainnetChainID, v1)
+	r.NoError(err)
+	r.Equal(AccountType, bound.Type())
+	r.Equal(MainnetChainID, bound.ChainID())
+	bound, err = BindChain(MainnetChainID, on1)
+	r.NoError(err)
+	r.Equal(on1, bound)
+	_, err = BindChain(TestnetChainID, on1)
+	r.True(errors.Is(err, ErrWrongChain))
+	_, err = BindChain(0, v1)
+	r.True(errors.Is(err, ErrInvalidAddr))
+	s, err := StringOnChain(MainnetChainID, on1)
+	r.NoError(err)
+	r.Equal(chain1Addr, s)
+	_, err = StringOnChain(TestnetChainID, on1)
+	r.True(errors.Is(err, ErrWrongChain))
+
+	// comparison
+	on2, err := FromString(chain2Addr)
+	r.NoError(err)
+	r.True(Equal(on1, on2))
+	r.False(EqualOnChain(on1, on2))
+	r.False(EqualOnChain(v1, on1))
+	r.True(EqualOnChain(on1, bound))
+	r.True(EqualOnChain(nil, nil))
+
+	// other networks
+	testnetAddr := StringOn(Testnet, on1)
+	_, err = NewCodec(Testnet).FromStringOnChain(MainnetChainID, testnetAddr)
+	r.NoError(err)
+	_, err = NewCodec(Testnet).FromStringOnChain(TestnetChainID, testnetAddr)
+	r.True(errors.Is(err, ErrWrongChain))
+
+	// parse options
+	_, _, err = Parse(chain2Addr, ParseOptions{ChainID: MainnetChainID, AllowV2: true})
+	r.True(errors.Is(err, ErrWrongChain))
+	_, _, err = Parse(chain1Addr, ParseOptions{ChainID: MainnetChainID, AllowV2: true})
+	r.NoError(err)
+	_, _, err = Parse(v1Addr, ParseOptions{ChainID: MainnetChainID})
+	r.NoError(err)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+// Codec converts addresses from and to their string form on a given network
+type Codec struct {
+	net Network
+}
+
+// NewCodec creates a codec for the network
+func NewCodec(net Network) *Codec {
+	return &Codec{net: net}
+}
+
+// Network returns the network of the codec
+func (c *Codec) Network() Network { return c.net }
+
+// FromString decodes an encoded address string into an address struct
+func (c *Codec) FromString(encodedAddr string) (Address, error) {
+	return FromStringOn(c.net, encodedAddr)
+}
+
+// FromStringLegacy decodes an encoded address string into an address struct
+func (c *Codec) FromStringLegacy(encodedAddr string) (Address, error) {
+	return FromStringLegacyOn(c.net, encodedAddr)
+}
+
+// FromBytes converts a byte array into an address struct
+func (c *Codec) FromBytes(bytes []byte) (Address, error) { return _v1.FromBytes(bytes) }
+
+// FromHex converts a hex-encoded string into an address struct
+func (c *Codec) FromHex(s string) (Address, error) { return _v1.FromHex(s) }
+
+// String encodes the address into a string on the codec's network
+func (c *Codec) String(addr Address) string {
+	return StringOn(c.net, addr)
+}
+
+// FromStringOnChain decodes an address string bound to the chain on the codec's network
+// An unbound address, or an address bound to another chain, returns ChainError
+func (c *Codec) FromStringOnChain(chainID uint32, encodedAddr string) (*AddrV2, error) {
+	return fromStringOnChain(c.net, chainID, encodedAddr)
+}
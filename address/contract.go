@@ -0,0 +1,67 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"github.com/pkg/errors"
+)
+
+// HashLength is the byte length of a 256-bit hash, e.g., the salt and init code hash used by CREATE2, or a signed hash
+const HashLength = 32
+
+// ContractAddress returns the address of the contract created by the deployer at the nonce, i.e., the last 20 bytes
+// of keccak256(rlp([deployer, nonce])), the same as the CREATE opcode of Ethereum
+func ContractAddress(deployer Address, nonce uint64) (Address, error) {
+	b, err := TryBytes(deployer)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidAddr, err.Error())
+	}
+	h := hash160b(rlpCreate(b, nonce))
+	return _v1.FromBytes(h[:])
+}
+
+// rlpCreate returns the rlp encoding of the list [deployer, nonce], whose total length is less than 56 bytes
+func rlpCreate(deployer []byte, nonce uint64) []byte {
+	rlp := make([]byte, 0, 2+len(deployer)+9)
+	rlp = append(rlp, 0, 0x80+byte(len(deployer)))
+	rlp = append(rlp, deployer...)
+	switch {
+	case nonce == 0:
+		rlp = append(rlp, 0x80)
+	case nonce < 0x80:
+		rlp = append(rlp, byte(nonce))
+	default:
+		var n []byte
+		for ; nonce > 0; nonce >>= 8 {
+			n = append([]byte{byte(nonce)}, n...)
+		}
+		rlp = append(rlp, 0x80+byte(len(n)))
+		rlp = append(rlp, n...)
+	}
+	rlp[0] = 0xc0 + byte(len(rlp)-1)
+	return rlp
+}
+
+// Create2Address returns the address of the contract created by the deployer with the salt and the keccak256 hash of
+// the init code, i.e., the last 20 bytes of keccak256(0xff ++ deployer ++ salt ++ initCodeHash), the same as the
+// CREATE2 opcode of Ethereum
+func Create2Address(deployer Address, salt [HashLength]byte, initCodeHash []byte) (Address, error) {
+	b, err := TryBytes(deployer)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidAddr, err.Error())
+	}
+	if len(initCodeHash) != HashLength {
+		return nil, errors.Errorf("init code hash length = %d, expecting %d", len(initCodeHash), HashLength)
+	}
+	h := hash160b([]byte{0xff}, b, salt[:], initCodeHash)
+	return _v1.FromBytes(h[:])
+}
+
+// InitCodeHash returns the keccak256 hash of the init code, to be used by Create2Address
+func InitCodeHash(initCode []byte) []byte {
+	return keccak256(initCode)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractAddress(t *testing.T) {
+	r := require.New(t)
+
+	deployer, err := FromHex("0x6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0")
+	r.NoError(err)
+	for _, v := range []struct {
+		nonce uint64
+		hex   string
+	}{
+		{0, "0xcd234a471b72ba2f1ccf0a70fcaba648a5eecd8d"},
+		{1, "0x343c43a37d37dff08ae8c4a11544c718abb4fcf8"},
+		{2, "0xf778b86fa74e846c4f0a1fbd1335fe81c00a0c91"},
+		{3, "0xfffd933a0bc612844eaf0c6fe3e5b8e9b6c1d19c"},
+	} {
+		addr, err := ContractAddress(deployer, v.nonce)
+		r.NoError(err)
+		r.Equal(v.hex, addr.Hex())
+		r.True(strings.HasPrefix(addr.String(), "io1"))
+	}
+
+	// rlp encoding of multi-byte nonces
+	for _, v := range []struct {
+		nonce uint64
+		rlp   string
+	}{
+		{0x7f, "d6946ac7ea33f8831ea9dcc53393aaa88b25a785dbf07f"},
+		{0x80, "d7946ac7ea33f8831ea9dcc53393aaa88b25a785dbf08180"},
+		{0x0400, "d8946ac7ea33f8831ea9dcc53393aaa88b25a785dbf0820400"},
+		{0xffffffffffffffff, "de946ac7ea33f8831ea9dcc53393aaa88b25a785dbf088ffffffffffffffff"},
+	} {
+		r.Equal(v.rlp, hex.EncodeToString(rlpCreate(deployer.Bytes(), v.nonce)))
+	}
+
+	special, err := FromString(RewardingPoolAddr)
+	r.NoError(err)
+	_, err = ContractAddress(special, 0)
+	r.Error(err)
+}
+
+func TestCreate2Address(t *testing.T) {
+	r := require.New(t)
+
+	// test vectors from https://eips.ethereum.org/EIPS/eip-1014
+	for _, v := range []struct {
+		deployer, salt, initCode, hex string
+	}{
+		{"0x0000000000000000000000000000000000000000",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"00",
+			"0x4d1a2e2bb4f88f0250f26ffff098b0b30b26bf38"},
+		{"0xdeadbeef00000000000000000000000000000000",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"00",
+			"0xb928f69bb1d91cd65274e3c79d8986362984fda3"},
+		{"0xdeadbeef00000000000000000000000000000000",
+			"000000000000000000000000feed000000000000000000000000000000000000",
+			"00",
+			"0xd04116cdd17bebe565eb2422f2497e06cc1c9833"},
+		{"0x0000000000000000000000000000000000000000",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"deadbeef",
+			"0x70f2b2914a2a4b783faefb75f459a580616fcb5e"},
+		{"0x00000000000000000000000000000000deadbeef",
+			"00000000000000000000000000000000000000000000000000000000cafebabe",
+			"deadbeef",
+			"0x60f3f640a8508fc6a86d45df051962668e1e8ac7"},
+		{"0x00000000000000000000000000000000deadbeef",
+			"00000000000000000000000000000000000000000000000000000000cafebabe",
+			"deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+			"0x1d8bfdc5d46dc4f61d6b6115972536ebe6a8854c"},
+		{"0x0000000000000000000000000000000000000000",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"",
+			"0xe33c0c7f7df4809055c3eba6c09cfe4baf1bd9e0"},
+	} {
+		deployer, err := FromHex(v.deployer)
+		r.NoError(err)
+		var salt [HashLength]byte
+		b, err := hex.DecodeString(v.salt)
+		r.NoError(err)
+		copy(salt[:], b)
+		initCode, err := hex.DecodeString(v.initCode)
+		r.NoError(err)
+		addr, err := Create2Address(deployer, salt, InitCodeHash(initCode))
+		r.NoError(err)
+		r.Equal(v.hex, addr.Hex())
+	}
+
+	deployer, err := FromHex("0x0000000000000000000000000000000000000000")
+	r.NoError(err)
+	_, err = Create2Address(deployer, [HashLength]byte{}, []byte{1, 2, 3})
+	r.Error(err)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-address/address/bech32"
+)
+
+// LocateErrors returns the positions of the characters that are likely mistyped in an encoded address string
+// Up to bech32.MaxCorrections substituted characters after the prefix can be located, and a nil slice is
+// returned if the checksum is valid
+func LocateErrors(encodedAddr string) ([]int, error) {
+	positions, err := bech32.LocateErrors(encodedAddr, bech32.Bech32)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidAddr, err.Error())
+	}
+	return positions, nil
+}
+
+// SuggestCorrections returns the valid addresses that differ from the encoded address string in one or two
+// characters. It is more expensive than FromString and meant to be called after FromString has failed
+func SuggestCorrections(encodedAddr string) []string {
+	return suggestCorrections(DefaultNetwork(), encodedAddr)
+}
+
+// SuggestCorrections returns the valid addresses of the codec's network that differ from the encoded address
+// string in one or two characters
+func (c *Codec) SuggestCorrections(encodedAddr string) []string {
+	return suggestCorrections(c.net, encodedAddr)
+}
+
+func suggestCorrections(net Network, encodedAddr string) []string {
+	// a valid address needs no correction, whatever its case
+	if _, err := FromStringOn(net, encodedAddr); err == nil {
+		return nil
+	}
+	candidates, err := bech32.Corrections(encodedAddr, bech32.Bech32)
+	if err != nil {
+		return nil
+	}
+	var suggestions []string
+	for _, c := range candidates {
+		// the candidates are lowercase
+		if strings.EqualFold(c, encodedAddr) {
+			continue
+		}
+		if _, err := FromStringOn(net, c); err == nil {
+			suggestions = append(suggestions, c)
+		}
+	}
+	return suggestions
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestCorrections(t *testing.T) {
+	r := require.New(t)
+
+	const valid = "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5"
+	positions, err := LocateErrors(valid)
+	r.NoError(err)
+	r.Nil(positions)
+	r.Empty(SuggestCorrections(valid))
+	// nor is a valid uppercase address corrected into its lowercase form
+	r.Empty(SuggestCorrections(strings.ToUpper(valid)))
+	r.Equal([]string{valid}, SuggestCorrections("IO1DJLZHWXDQQAHHWHDXTN8HKHPPVNNRPTQTWF2H5"))
+
+	for _, v := range []struct {
+		addr      string
+		positions []int
+	}{
+		{"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h4", []int{40}},         // wrong checksum character
+		{"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h", nil},                // missing character
+		{"io1djlzhwxdqqahhwhdxtn8hkhppvnnrptqtwf2h5", []int{22}},         // wrong payload character
+		{"io1djlzhwxdqqahhwhdxtn8hkhppvnnrptqtwf2k5", []int{22, 39}},     // two wrong characters
+		{"io1djlzhwxdqqahhwhdxtnbhkhppvnnrptqtwf2h5", []int{22}},         // character not in the charset
+		{"io1djlzhwxdqqahhwhdxtnbhkhppvnnrptqtwf2hs", []int{22, 40}},     // not in the charset and wrong
+		{"IO1DJLZHWXDQQAHHWHDXTN8HKHPPVNNRPTQTWF2H5", []int{22}},         // uppercase
+		{"io1djlzhwxdqqahhwhdxtn8hkhppvnnrptqtwf2kk", []int{22, 39, 40}}, // too many errors
+	} {
+		positions, err := LocateErrors(v.addr)
+		if len(v.positions) == 0 || len(v.positions) > 2 {
+			r.True(errors.Is(err, ErrInvalidAddr))
+			r.Empty(SuggestCorrections(v.addr))
+			continue
+		}
+		r.NoError(err)
+		r.Equal(v.positions, positions)
+		r.Equal([]string{valid}, SuggestCorrections(v.addr))
+	}
+
+	// suggestions are restricted to the codec's network
+	r.Empty(NewCodec(Testnet).SuggestCorrections("io1djlzhwxdqqahhwhdxtn8hkhppvnnrptqtwf2h5"))
+	r.Equal([]string{"it1djlzhwxdqqahhwhdxtn9hkhppvnnrptqg05fuh"},
+		NewCodec(Testnet).SuggestCorrections("it1djlzhwxdqqahhwhdxtn9hkhppvnnrptqg05fuu"))
+}
@@ -0,0 +1,466 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package eip712 hashes EIP-712 typed data, as signed by eth_signTypedData_v4, and recovers the signer address
+//
+// The value of an address field is any string address.FromString or address.FromHexChecked accepts, e.g., io1... or
+// 0x..., an address.Address, or 20 bytes, and is encoded as its 20-byte Bytes value. A typed data decoded from JSON holds
+// numbers as float64, so a json.Decoder with UseNumber should be used for numbers beyond 2^53, or they may be given
+// as decimal or "0x"-prefixed hex strings.
+package eip712
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+// DomainType is the name of the type of the domain
+const DomainType = "EIP712Domain"
+
+// domainFields are the fields of the domain in their canonical order
+var domainFields = []Field{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+	{Name: "salt", Type: "bytes32"},
+}
+
+var (
+	// typeNameRegexp matches the name of a struct type
+	typeNameRegexp = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+	// arrayRegexp matches an array type, capturing the element type and the optional length
+	arrayRegexp = regexp.MustCompile(`^(.+)\[([0-9]*)\]$`)
+)
+
+type (
+	// Field is a member of a struct type
+	Field struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+
+	// Types are the struct types by name
+	Types map[string][]Field
+
+	// TypedData is the typed data signed by eth_signTypedData_v4
+	TypedData struct {
+		Types       Types                  `json:"types"`
+		PrimaryType string                 `json:"primaryType"`
+		Domain      map[string]interface{} `json:"domain"`
+		Message     map[string]interface{} `json:"message"`
+	}
+)
+
+// Hash returns the hash to be signed, i.e., keccak256(0x19 0x01 ‖ domainSeparator ‖ hashStruct(message))
+func (td *TypedData) Hash() ([]byte, error) {
+	domain, err := td.DomainSeparator()
+	if err != nil {
+		return nil, err
+	}
+	message, err := td.HashStruct(td.PrimaryType, td.Message)
+	if err != nil {
+		return nil, err
+	}
+	return keccak256([]byte{0x19, 0x01}, domain, message), nil
+}
+
+// DomainSeparator returns the hash of the domain
+// If the types do not define EIP712Domain, it is made of the fields present in the domain in the canonical order
+func (td *TypedData) DomainSeparator() ([]byte, error) {
+	if _, ok := td.Types[DomainType]; ok {
+		return td.HashStruct(DomainType, td.Domain)
+	}
+	fields := make([]Field, 0, len(domainFields))
+	for _, f := range domainFields {
+		if _, ok := td.Domain[f.Name]; ok {
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) != len(td.Domain) {
+		return nil, errors.Wrap(ErrInvalidTypedData, "unknown domain field without EIP712Domain type")
+	}
+	types := make(Types, len(td.Types)+1)
+	for k, v := range td.Types {
+		types[k] = v
+	}
+	types[DomainType] = fields
+	return (&TypedData{Types: types}).HashStruct(DomainType, td.Domain)
+}
+
+// RecoverSigner recovers the address that signed the typed data, sig being R || S || V
+func (td *TypedData) RecoverSigner(sig []byte) (address.Address, error) {
+	hash, err := td.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return address.RecoverAddress(hash, sig)
+}
+
+// Verify returns nil if the address signed the typed data, which may be of any form sharing the hash of the signer
+func (td *TypedData) Verify(addr address.Address, sig []byte) error {
+	signer, err := td.RecoverSigner(sig)
+	if err != nil {
+		return err
+	}
+	if !address.Equal(addr, signer) {
+		return errors.Wrapf(address.ErrWrongSigner, "signed by %s, expecting %s", signer, addr)
+	}
+	return nil
+}
+
+// HashStruct returns keccak256(typeHash ‖ encodeData(data)) of the struct type
+func (td *TypedData) HashStruct(typeName string, data map[string]interface{}) ([]byte, error) {
+	enc, err := td.EncodeData(typeName, data)
+	if err != nil {
+		return nil, err
+	}
+	return keccak256(enc), nil
+}
+
+// TypeHash returns the keccak256 hash of the encoded type
+func (td *TypedData) TypeHash(typeName string) ([]byte, error) {
+	s, err := td.EncodeType(typeName)
+	if err != nil {
+		return nil, err
+	}
+	return keccak256([]byte(s)), nil
+}
+
+// EncodeType encodes the struct type followed by the struct types it references sorted by name, e.g.,
+// Mail(Person from,Person to,string contents)Person(string name,address wallet)
+func (td *TypedData) EncodeType(typeName string) (string, error) {
+	deps := map[string]bool{}
+	if err := td.dependencies(typeName, deps); err != nil {
+		return "", err
+	}
+	delete(deps, typeName)
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range append([]string{typeName}, names...) {
+		b.WriteString(name)
+		b.WriteByte('(')
+		for i, f := range td.Types[name] {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(f.Type)
+			b.WriteByte(' ')
+			b.WriteString(f.Name)
+		}
+		b.WriteByte(')')
+	}
+	return b.String(), nil
+}
+
+// dependencies adds the struct type and the struct types it references to deps
+func (td *TypedData) dependencies(typeName string, deps map[string]bool) error {
+	if deps[typeName] {
+		return nil
+	}
+	fields, ok := td.Types[typeName]
+	if !ok {
+		return errors.Wrapf(ErrInvalidTypedData, "undefined type %s", typeName)
+	}
+	if !typeNameRegexp.MatchString(typeName) {
+		return errors.Wrapf(ErrInvalidTypedData, "invalid type name %q", typeName)
+	}
+	deps[typeName] = true
+	for _, f := range fields {
+		t := baseType(f.Type)
+		if _, ok := td.Types[t]; ok {
+			if err := td.dependencies(t, deps); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// EncodeData encodes the fields of the struct type, without the type hash
+func (td *TypedData) EncodeData(typeName string, data map[string]interface{}) ([]byte, error) {
+	typeHash, err := td.TypeHash(typeName)
+	if err != nil {
+		return nil, err
+	}
+	fields := td.Types[typeName]
+	known := make(map[string]bool, len(fields))
+	buf := bytes.NewBuffer(make([]byte, 0, 32*(len(fields)+1)))
+	buf.Write(typeHash)
+	for _, f := range fields {
+		known[f.Name] = true
+		enc, err := td.encodeValue(f.Type, data[f.Name], typeName+"."+f.Name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(enc)
+	}
+	for name := range data {
+		if !known[name] {
+			return nil, &FieldError{Field: typeName + "." + name, Err: errors.Wrap(ErrInvalidTypedData, "undefined field")}
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeValue encodes the value of the type into 32 bytes
+func (td *TypedData) encodeValue(typ string, v interface{}, field string) ([]byte, error) {
+	if m := arrayRegexp.FindStringSubmatch(typ); m != nil {
+		items, ok := toSlice(v)
+		if !ok {
+			return nil, fieldErrorf(field, "%T is not an array", v)
+		}
+		if m[2] != "" {
+			if n, err := strconv.Atoi(m[2]); err != nil || n != len(items) {
+				return nil, fieldErrorf(field, "array length = %d, expecting %s", len(items), m[2])
+			}
+		}
+		var buf bytes.Buffer
+		for i, item := range items {
+			enc, err := td.encodeValue(m[1], item, fmt.Sprintf("%s[%d]", field, i))
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(enc)
+		}
+		return keccak256(buf.Bytes()), nil
+	}
+	if _, ok := td.Types[typ]; ok {
+		data, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fieldErrorf(field, "%T is not a struct", v)
+		}
+		return td.HashStruct(typ, data)
+	}
+
+	var (
+		enc []byte
+		err error
+	)
+	switch {
+	case typ == "address":
+		enc, err = encodeAddress(v)
+	case typ == "bool":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fieldErrorf(field, "%T is not a bool", v)
+		}
+		enc = make([]byte, 32)
+		if b {
+			enc[31] = 1
+		}
+	case typ == "string":
+		s, ok := v.(string)
+		if !ok {
+			return nil, fieldErrorf(field, "%T is not a string", v)
+		}
+		enc = keccak256([]byte(s))
+	case typ == "bytes":
+		var b []byte
+		if b, err = toBytes(v); err == nil {
+			enc = keccak256(b)
+		}
+	case strings.HasPrefix(typ, "bytes"):
+		enc, err = encodeFixedBytes(typ, v)
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		enc, err = encodeInteger(typ, v)
+	default:
+		return nil, fieldErrorf(field, "undefined type %s", typ)
+	}
+	if err != nil {
+		return nil, &FieldError{Field: field, Err: err}
+	}
+	return enc, nil
+}
+
+// encodeAddress encodes the 20-byte hash of the address, left-padded to 32 bytes
+func encodeAddress(v interface{}) ([]byte, error) {
+	var (
+		addr address.Address
+		err  error
+	)
+	switch v := v.(type) {
+	case address.Address:
+		addr = v
+	case string:
+		// a hex address must be exactly 20 bytes, rather than cropped or padded to them
+		if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+			addr, err = address.FromHexChecked(v)
+		} else {
+			addr, err = address.FromString(v)
+		}
+	case []byte:
+		if len(v) != 20 {
+			return nil, errors.Wrapf(address.ErrInvalidAddr, "address length = %d, expecting 20", len(v))
+		}
+		addr, err = address.FromBytes(v)
+	default:
+		return nil, errors.Wrapf(ErrInvalidTypedData, "%T is not an address", v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	b, err := address.TryBytes(addr)
+	if err != nil {
+		return nil, err
+	}
+	return leftPad(b), nil
+}
+
+// encodeFixedBytes encodes bytes1 to bytes32, right-padded to 32 bytes
+func encodeFixedBytes(typ string, v interface{}) ([]byte, error) {
+	n, err := strconv.Atoi(typ[len("bytes"):])
+	if err != nil || n < 1 || n > 32 {
+		return nil, errors.Wrapf(ErrInvalidTypedData, "undefined type %s", typ)
+	}
+	b, err := toBytes(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != n {
+		return nil, errors.Wrapf(ErrInvalidTypedData, "%s of %d bytes", typ, len(b))
+	}
+	enc := make([]byte, 32)
+	copy(enc, b)
+	return enc, nil
+}
+
+// encodeInteger encodes uint8 to uint256 and int8 to int256 as a 32-byte two's complement
+func encodeInteger(typ string, v interface{}) ([]byte, error) {
+	signed := strings.HasPrefix(typ, "int")
+	bits, err := strconv.Atoi(strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int"))
+	if err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+		return nil, errors.Wrapf(ErrInvalidTypedData, "undefined type %s", typ)
+	}
+	n, err := toBigInt(v)
+	if err != nil {
+		return nil, err
+	}
+	min, max := big.NewInt(0), new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	if signed {
+		max.Rsh(max, 1)
+		min.Neg(max)
+	}
+	if n.Cmp(min) < 0 || n.Cmp(max) >= 0 {
+		return nil, errors.Wrapf(ErrInvalidTypedData, "%s out of range of %s", n, typ)
+	}
+	if n.Sign() < 0 {
+		n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	return leftPad(n.Bytes()), nil
+}
+
+// toBigInt converts an integer, a float64 or json.Number of an integer, or a decimal or "0x"-prefixed hex string
+func toBigInt(v interface{}) (*big.Int, error) {
+	switch v := v.(type) {
+	case *big.Int:
+		if v != nil {
+			return v, nil
+		}
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) <= 1<<53 {
+			return big.NewInt(int64(v)), nil
+		}
+		return nil, errors.Wrapf(ErrInvalidTypedData, "%v is not an exact integer", v)
+	case json.Number:
+		return toBigInt(string(v))
+	case string:
+		s, base := v, 10
+		neg := strings.HasPrefix(s, "-")
+		if neg {
+			s = s[1:]
+		}
+		if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+			s, base = s[2:], 16
+		}
+		if n, ok := new(big.Int).SetString(s, base); ok && s != "" && s[0] != '+' && s[0] != '-' {
+			if neg {
+				n.Neg(n)
+			}
+			return n, nil
+		}
+		return nil, errors.Wrapf(ErrInvalidTypedData, "malformed integer %q", v)
+	}
+	return nil, errors.Wrapf(ErrInvalidTypedData, "%T is not an integer", v)
+}
+
+// toBytes converts []byte or a "0x"-prefixed hex string
+func toBytes(v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+			if b, err := hex.DecodeString(v[2:]); err == nil {
+				return b, nil
+			}
+		}
+		return nil, errors.Wrapf(ErrInvalidTypedData, "malformed hex bytes %q", v)
+	}
+	return nil, errors.Wrapf(ErrInvalidTypedData, "%T is not bytes", v)
+}
+
+// toSlice converts a slice or array of any element type
+func toSlice(v interface{}) ([]interface{}, bool) {
+	if items, ok := v.([]interface{}); ok {
+		return items, true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	items := make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items, true
+}
+
+// baseType strips the array suffixes from the type, e.g., Person for Person[][2]
+func baseType(typ string) string {
+	if i := strings.IndexByte(typ, '['); i >= 0 {
+		return typ[:i]
+	}
+	return typ
+}
+
+func leftPad(b []byte) []byte {
+	enc := make([]byte, 32)
+	copy(enc[32-len(b):], b)
+	return enc
+}
+
+func keccak256(input ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, b := range input {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
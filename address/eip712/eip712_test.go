@@ -0,0 +1,236 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package eip712
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+// the example of https://eips.ethereum.org/EIPS/eip-712, signed by the private key keccak256("cow")
+const mailJSON = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+const (
+	mailTypeHash        = "a0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2"
+	mailDomainSeparator = "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"
+	mailStructHash      = "c52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e"
+	mailHash            = "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"
+	mailSignature       = "4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d" +
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562" + "1c"
+	cowWallet = "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"
+)
+
+func decodeMail(r *require.Assertions) *TypedData {
+	var td TypedData
+	d := json.NewDecoder(bytes.NewReader([]byte(mailJSON)))
+	d.UseNumber()
+	r.NoError(d.Decode(&td))
+	return &td
+}
+
+func TestMail(t *testing.T) {
+	r := require.New(t)
+
+	td := decodeMail(r)
+	typ, err := td.EncodeType("Mail")
+	r.NoError(err)
+	r.Equal("Mail(Person from,Person to,string contents)Person(string name,address wallet)", typ)
+	h, err := td.TypeHash("Mail")
+	r.NoError(err)
+	r.Equal(mailTypeHash, hex.EncodeToString(h))
+	h, err = td.DomainSeparator()
+	r.NoError(err)
+	r.Equal(mailDomainSeparator, hex.EncodeToString(h))
+	h, err = td.HashStruct("Mail", td.Message)
+	r.NoError(err)
+	r.Equal(mailStructHash, hex.EncodeToString(h))
+	h, err = td.Hash()
+	r.NoError(err)
+	r.Equal(mailHash, hex.EncodeToString(h))
+
+	sig, err := hex.DecodeString(mailSignature)
+	r.NoError(err)
+	cow, err := address.FromHexChecked(cowWallet)
+	r.NoError(err)
+	signer, err := td.RecoverSigner(sig)
+	r.NoError(err)
+	r.True(address.Equal(cow, signer))
+	r.NoError(td.Verify(cow, sig))
+	bob, err := address.FromHex("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB")
+	r.NoError(err)
+	r.True(errors.Is(td.Verify(bob, sig), address.ErrWrongSigner))
+
+	// the same hash for every form of the addresses
+	contract, err := address.FromHex("0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC")
+	r.NoError(err)
+	v2, err := address.ToV2(cow, address.AccountType, address.MainnetChainID)
+	r.NoError(err)
+	td.Domain["verifyingContract"] = contract.String()
+	td.Domain["chainId"] = big.NewInt(1)
+	for _, wallet := range []interface{}{
+		cow.String(),
+		v2.String(),
+		cow.Hex(),
+		cow,
+		cow.Bytes(),
+	} {
+		td.Message["from"].(map[string]interface{})["wallet"] = wallet
+		h, err = td.Hash()
+		r.NoError(err)
+		r.Equal(mailHash, hex.EncodeToString(h))
+	}
+
+	// the canonical domain fields, if the types do not define EIP712Domain
+	delete(td.Types, DomainType)
+	h, err = td.DomainSeparator()
+	r.NoError(err)
+	r.Equal(mailDomainSeparator, hex.EncodeToString(h))
+	td.Domain["chain"] = 1
+	_, err = td.DomainSeparator()
+	r.True(errors.Is(err, ErrInvalidTypedData))
+	delete(td.Domain, "chain")
+
+	// a verifyingContract of 32 bytes is not an address
+	td.Domain["verifyingContract"] = "0x000000000000000000000000CcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	_, err = td.DomainSeparator()
+	r.True(errors.Is(err, address.ErrInvalidAddr))
+	_, err = td.Hash()
+	r.True(errors.Is(err, ErrInvalidTypedData))
+}
+
+func TestEncodeData(t *testing.T) {
+	r := require.New(t)
+
+	td := &TypedData{Types: Types{
+		"Order": {
+			{Name: "owner", Type: "address"},
+			{Name: "amount", Type: "uint256"},
+			{Name: "delta", Type: "int8"},
+			{Name: "active", Type: "bool"},
+			{Name: "id", Type: "bytes4"},
+			{Name: "data", Type: "bytes"},
+			{Name: "tags", Type: "string[]"},
+			{Name: "legs", Type: "Leg[2]"},
+		},
+		"Leg": {{Name: "price", Type: "uint64"}},
+	}}
+	typ, err := td.EncodeType("Order")
+	r.NoError(err)
+	r.Equal("Order(address owner,uint256 amount,int8 delta,bool active,bytes4 id,bytes data,string[] tags,Leg[2] legs)"+
+		"Leg(uint64 price)", typ)
+
+	order := map[string]interface{}{
+		"owner":  "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5",
+		"amount": "1000000000000000000",
+		"delta":  -1,
+		"active": true,
+		"id":     "0x01020304",
+		"data":   []byte{5, 6},
+		"tags":   []string{"a", "b"},
+		"legs": []interface{}{
+			map[string]interface{}{"price": json.Number("7")},
+			map[string]interface{}{"price": "0x8"},
+		},
+	}
+	enc, err := td.EncodeData("Order", order)
+	r.NoError(err)
+	r.Len(enc, 32*9)
+	typeHash, err := td.TypeHash("Order")
+	r.NoError(err)
+	r.Equal(typeHash, enc[:32])
+	r.Equal("0000000000000000000000006cbe2bb8cd003b7bbaed32e65bdae10b27318560", hex.EncodeToString(enc[32:64]))
+	r.Equal("0000000000000000000000000000000000000000000000000de0b6b3a7640000", hex.EncodeToString(enc[64:96]))
+	r.Equal("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", hex.EncodeToString(enc[96:128]))
+	r.Equal("0000000000000000000000000000000000000000000000000000000000000001", hex.EncodeToString(enc[128:160]))
+	r.Equal("0102030400000000000000000000000000000000000000000000000000000000", hex.EncodeToString(enc[160:192]))
+	r.Equal(keccak256([]byte{5, 6}), enc[192:224])
+	r.Equal(keccak256(keccak256([]byte("a")), keccak256([]byte("b"))), enc[224:256])
+
+	for field, v := range map[string]interface{}{
+		"owner":  "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h4",
+		"amount": -1,
+		"delta":  128,
+		"active": "true",
+		"id":     "0x0102",
+		"data":   "0506",
+		"tags":   "a",
+		"legs":   []interface{}{map[string]interface{}{"price": 1}},
+	} {
+		invalid := make(map[string]interface{}, len(order))
+		for k, v := range order {
+			invalid[k] = v
+		}
+		invalid[field] = v
+		_, err = td.EncodeData("Order", invalid)
+		var fieldErr *FieldError
+		r.True(errors.As(err, &fieldErr), field)
+		r.Contains(fieldErr.Field, "Order."+field)
+		r.True(errors.Is(err, ErrInvalidTypedData))
+	}
+	// an invalid address is reported as the address error
+	order["owner"] = "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h4"
+	_, err = td.EncodeData("Order", order)
+	r.True(errors.Is(err, address.ErrInvalidAddr))
+	// a hex address is neither cropped nor padded to 20 bytes, and a mixed-case one must match its checksum
+	for _, owner := range []string{
+		"0x0000000000000000000000006cbe2bb8cd003b7bbaed32e65bdae10b27318560",
+		"0x6cbe2bb8cd003b7bbaed32e65bdae10b273185",
+		"0x6CBE2bb8cd003b7bbaed32e65bdae10b27318560",
+	} {
+		order["owner"] = owner
+		_, err = td.EncodeData("Order", order)
+		r.True(errors.Is(err, address.ErrInvalidAddr), owner)
+		r.True(errors.Is(err, ErrInvalidTypedData), owner)
+	}
+	order["owner"] = address.RewardingPoolAddr
+	_, err = td.EncodeData("Order", order)
+	r.True(errors.Is(err, ErrInvalidTypedData))
+
+	order["unknown"] = 1
+	_, err = td.EncodeData("Order", order)
+	r.True(errors.Is(err, ErrInvalidTypedData))
+	_, err = td.EncodeType("Missing")
+	r.True(errors.Is(err, ErrInvalidTypedData))
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package eip712
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidTypedData indicates the invalid typed data error, which every error of the package wraps
+var ErrInvalidTypedData = errors.New("invalid typed data")
+
+// FieldError reports a field whose value cannot be encoded, Err being the cause, e.g., an address error
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+// Error returns the error message
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %s: %v", e.Field, e.Err)
+}
+
+// Unwrap returns the cause
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// Is returns true for ErrInvalidTypedData
+func (e *FieldError) Is(target error) bool { return target == ErrInvalidTypedData }
+
+func fieldErrorf(field, format string, args ...interface{}) error {
+	return &FieldError{Field: field, Err: errors.Wrapf(ErrInvalidTypedData, format, args...)}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-address/address/bech32"
+)
+
+// The decode errors below all wrap ErrInvalidAddr, so both errors.Is(err, ErrInvalidAddr) and errors.Cause(err) ==
+// ErrInvalidAddr hold for them, and they can be inspected with errors.As. Those reported by the bech32 decoder also
+// match the corresponding bech32 sentinel error.
+
+type (
+	// LengthError reports an address string of the wrong length
+	LengthError struct {
+		Got  int
+		Want int
+	}
+
+	// CharError reports an invalid character and its position in the address string
+	CharError struct {
+		Pos  int
+		Char byte
+	}
+
+	// MixedCaseError reports an address string that is neither all lowercase nor all uppercase
+	MixedCaseError struct{}
+
+	// ChecksumError reports an address string whose checksum does not match
+	ChecksumError struct {
+		Expected string
+		Got      string
+	}
+
+	// HRPError reports an address string whose prefix does not match the network
+	HRPError struct {
+		Got  string
+		Want string
+	}
+
+	// PaddingError reports an address string whose payload is not a whole number of bytes
+	PaddingError struct{}
+
+	// VersionError reports an address string of an unsupported version
+	VersionError struct {
+		Version int
+	}
+)
+
+// Error returns the error message
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("address length = %d, expecting %d: %v", e.Got, e.Want, ErrInvalidAddr)
+}
+
+// Unwrap returns ErrInvalidAddr
+func (e *LengthError) Unwrap() error { return ErrInvalidAddr }
+
+// Cause returns ErrInvalidAddr, for errors.Cause of github.com/pkg/errors
+func (e *LengthError) Cause() error { return ErrInvalidAddr }
+
+// Is returns true for bech32.ErrInvalidLength
+func (e *LengthError) Is(target error) bool { return target == bech32.ErrInvalidLength }
+
+// Error returns the error message
+func (e *CharError) Error() string {
+	return fmt.Sprintf("invalid character '%c' at position %d: %v", e.Char, e.Pos, ErrInvalidAddr)
+}
+
+// Unwrap returns ErrInvalidAddr
+func (e *CharError) Unwrap() error { return ErrInvalidAddr }
+
+// Cause returns ErrInvalidAddr, for errors.Cause of github.com/pkg/errors
+func (e *CharError) Cause() error { return ErrInvalidAddr }
+
+// Is returns true for bech32.ErrInvalidCharacter
+func (e *CharError) Is(target error) bool { return target == bech32.ErrInvalidCharacter }
+
+// Error returns the error message
+func (e *MixedCaseError) Error() string {
+	return fmt.Sprintf("address not all lowercase or all uppercase: %v", ErrInvalidAddr)
+}
+
+// Unwrap returns ErrInvalidAddr
+func (e *MixedCaseError) Unwrap() error { return ErrInvalidAddr }
+
+// Cause returns ErrInvalidAddr, for errors.Cause of github.com/pkg/errors
+func (e *MixedCaseError) Cause() error { return ErrInvalidAddr }
+
+// Is returns true for bech32.ErrMixedCase
+func (e *MixedCaseError) Is(target error) bool { return target == bech32.ErrMixedCase }
+
+// Error returns the error message
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("checksum failed: Expected %s, got %s.: %v", e.Expected, e.Got, ErrInvalidAddr)
+}
+
+// Unwrap returns ErrInvalidAddr
+func (e *ChecksumError) Unwrap() error { return ErrInvalidAddr }
+
+// Cause returns ErrInvalidAddr, for errors.Cause of github.com/pkg/errors
+func (e *ChecksumError) Cause() error { return ErrInvalidAddr }
+
+// Is returns true for bech32.ErrInvalidChecksum
+func (e *ChecksumError) Is(target error) bool { return target == bech32.ErrInvalidChecksum }
+
+// Error returns the error message
+func (e *HRPError) Error() string {
+	return fmt.Sprintf("hrp %s and address prefix %s don't match: %v", e.Got, e.Want, ErrInvalidAddr)
+}
+
+// Unwrap returns ErrInvalidAddr
+func (e *HRPError) Unwrap() error { return ErrInvalidAddr }
+
+// Cause returns ErrInvalidAddr, for errors.Cause of github.com/pkg/errors
+func (e *HRPError) Cause() error { return ErrInvalidAddr }
+
+// Error returns the error message
+func (e *PaddingError) Error() string {
+	return fmt.Sprintf("invalid incomplete group: %v", ErrInvalidAddr)
+}
+
+// Unwrap returns ErrInvalidAddr
+func (e *PaddingError) Unwrap() error { return ErrInvalidAddr }
+
+// Cause returns ErrInvalidAddr, for errors.Cause of github.com/pkg/errors
+func (e *PaddingError) Cause() error { return ErrInvalidAddr }
+
+// Is returns true for bech32.ErrInvalidPadding
+func (e *PaddingError) Is(target error) bool { return target == bech32.ErrInvalidPadding }
+
+// Error returns the error message
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("unsupported address version %d: %v", e.Version, ErrInvalidAddr)
+}
+
+// Unwrap returns ErrInvalidAddr
+func (e *VersionError) Unwrap() error { return ErrInvalidAddr }
+
+// Cause returns ErrInvalidAddr, for errors.Cause of github.com/pkg/errors
+func (e *VersionError) Cause() error { return ErrInvalidAddr }
+
+// fromBech32Error converts an error of the bech32 decoder into the corresponding decode error, want being the expected
+// length of the address string
+func fromBech32Error(err error, encodedAddr string, want int) error {
+	var (
+		charErr     *bech32.CharError
+		checksumErr *bech32.ChecksumError
+	)
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &charErr):
+		return &CharError{Pos: charErr.Pos, Char: charErr.Char}
+	case errors.As(err, &checksumErr):
+		return &ChecksumError{Expected: checksumErr.Expected, Got: checksumErr.Got}
+	case errors.Is(err, bech32.ErrMixedCase):
+		return &MixedCaseError{}
+	case errors.Is(err, bech32.ErrInvalidLength):
+		return &LengthError{Got: len(encodedAddr), Want: want}
+	case errors.Is(err, bech32.ErrInvalidPadding):
+		return &PaddingError{}
+	default:
+		return errors.Wrap(ErrInvalidAddr, err.Error())
+	}
+}
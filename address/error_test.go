@@ -0,0 +1,93 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-address/address/bech32"
+)
+
+func TestDecodeErrors(t *testing.T) {
+	r := require.New(t)
+
+	for _, v := range []struct {
+		addr     string
+		err      error
+		sentinel error
+	}{
+		{"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h", &LengthError{Got: 40, Want: 41}, bech32.ErrInvalidLength},
+		{"io1djlzhwxdqqahhwhdxtnbhkhppvnnrptqtwf2h5", &CharError{Pos: 22, Char: 'b'}, bech32.ErrInvalidCharacter},
+		{"io1djlzhwxdqqahhwhdxtn hkhppvnnrptqtwf2h5", &CharError{Pos: 22, Char: ' '}, bech32.ErrInvalidCharacter},
+		{"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtWf2h5", &MixedCaseError{}, bech32.ErrMixedCase},
+		{"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h4", &ChecksumError{Expected: "twf2h5", Got: "twf2h4"}, bech32.ErrInvalidChecksum},
+		{"it1djlzhwxdqqahhwhdxtn9hkhppvnnrptqg05fuh", &HRPError{Got: "it", Want: "io"}, nil},
+		{"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5", nil, nil},
+	} {
+		_, err := FromStringOn(Mainnet, v.addr)
+		if v.err == nil {
+			r.NoError(err)
+			continue
+		}
+		r.Equal(v.err, err)
+		r.True(errors.Is(err, ErrInvalidAddr))
+		r.Equal(ErrInvalidAddr, errors.Cause(err))
+		if v.sentinel != nil {
+			r.True(errors.Is(err, v.sentinel))
+		}
+	}
+
+	// details can be extracted with errors.As
+	_, err := FromStringOn(Mainnet, "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h4")
+	var checksumErr *ChecksumError
+	r.True(errors.As(err, &checksumErr))
+	r.Equal("twf2h5", checksumErr.Expected)
+	_, err = FromHexChecked("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD")
+	r.True(errors.As(err, &checksumErr))
+	r.Equal("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", checksumErr.Expected)
+	_, err = FromHexChecked("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAgd")
+	var charErr *CharError
+	r.True(errors.As(err, &charErr))
+	r.Equal(40, charErr.Pos)
+	var lengthErr *LengthError
+	_, err = FromHexChecked("0x5aAeb6")
+	r.True(errors.As(err, &lengthErr))
+	r.Equal(42, lengthErr.Want)
+
+	// a payload that is not a whole number of bytes
+	_, err = FromStringLegacy("io1djlzhwxdqqahhwhdxtn9hkhppvnnrp726csn")
+	var paddingErr *PaddingError
+	r.True(errors.As(err, &paddingErr))
+	r.True(errors.Is(err, ErrInvalidAddr))
+	r.True(errors.Is(err, bech32.ErrInvalidPadding))
+}
+
+func TestLengthErrorWant(t *testing.T) {
+	r := require.New(t)
+
+	// the expected length follows the network prefix
+	sub, err := RegisterNetwork("subchain", "sub")
+	r.NoError(err)
+	for _, v := range []struct {
+		net  Network
+		want int
+	}{
+		{Mainnet, V1AddressStringLength},
+		{sub, len(sub.Prefix()) + v1PayloadStringLength},
+	} {
+		_, err = FromStringLegacyOn(v.net, v.net.Prefix()+"1"+strings.Repeat("q", 100))
+		var lengthErr *LengthError
+		r.True(errors.As(err, &lengthErr))
+		r.Equal(v.want, lengthErr.Want)
+		r.True(errors.Is(err, bech32.ErrInvalidLength))
+	}
+	r.Equal(&LengthError{Got: 100, Want: 49}, fromBech32Error(bech32.ErrInvalidLength, strings.Repeat("q", 100), 49))
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package hd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var (
+	bigRadix = big.NewInt(58)
+	bigZero  = big.NewInt(0)
+)
+
+// base58CheckEncode encodes the payload followed by the first 4 bytes of its double SHA-256 hash in base58
+func base58CheckEncode(payload []byte) string {
+	b := make([]byte, 0, len(payload)+4)
+	b = append(b, payload...)
+	b = append(b, checksum(payload)...)
+
+	x := new(big.Int).SetBytes(b)
+	mod := new(big.Int)
+	encoded := make([]byte, 0, len(b)*138/100+1)
+	for x.Cmp(bigZero) > 0 {
+		x.DivMod(x, bigRadix, mod)
+		encoded = append(encoded, base58Alphabet[mod.Int64()])
+	}
+	// leading zero bytes are encoded as '1'
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
+
+// base58CheckDecode decodes the base58 string, and verifies and strips the checksum
+func base58CheckDecode(s string) ([]byte, error) {
+	x := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		d := bytes.IndexByte([]byte(base58Alphabet), s[i])
+		if d < 0 {
+			return nil, errors.Wrapf(ErrInvalidKey, "invalid base58 character %q at position %d", s[i], i)
+		}
+		x.Mul(x, bigRadix)
+		x.Add(x, big.NewInt(int64(d)))
+	}
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	b := append(make([]byte, zeros), x.Bytes()...)
+	if len(b) < 4 {
+		return nil, errors.Wrap(ErrInvalidKey, "missing checksum")
+	}
+	payload, sum := b[:len(b)-4], b[len(b)-4:]
+	if !bytes.Equal(checksum(payload), sum) {
+		return nil, errors.Wrap(ErrInvalidKey, "checksum mismatch")
+	}
+	return payload, nil
+}
+
+// checksum returns the first 4 bytes of the double SHA-256 hash
+func checksum(b []byte) []byte {
+	h := sha256.Sum256(b)
+	h = sha256.Sum256(h[:])
+	return h[:4]
+}
@@ -0,0 +1,304 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package hd implements BIP-32 hierarchical deterministic derivation of secp256k1 keys, and derives IoTeX addresses
+// along the BIP-44 path m/44'/304'/account'/change/index
+package hd
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v3"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ripemd160"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+const (
+	// MinSeedLength is the minimum byte length of a seed
+	MinSeedLength = 16
+	// MaxSeedLength is the maximum byte length of a seed
+	MaxSeedLength = 64
+	// serializedKeyLength is the byte length of a serialized extended key without checksum
+	serializedKeyLength = 78
+)
+
+var (
+	// masterKey is the HMAC key to derive the master key from a seed
+	masterKey = []byte("Bitcoin seed")
+
+	// MainnetPrivateVersion is the version bytes of a serialized private key, i.e., xprv
+	MainnetPrivateVersion = [4]byte{0x04, 0x88, 0xad, 0xe4}
+	// MainnetPublicVersion is the version bytes of a serialized public key, i.e., xpub
+	MainnetPublicVersion = [4]byte{0x04, 0x88, 0xb2, 0x1e}
+	// TestnetPrivateVersion is the version bytes of a serialized testnet private key, i.e., tprv
+	TestnetPrivateVersion = [4]byte{0x04, 0x35, 0x83, 0x94}
+	// TestnetPublicVersion is the version bytes of a serialized testnet public key, i.e., tpub
+	TestnetPublicVersion = [4]byte{0x04, 0x35, 0x87, 0xcf}
+)
+
+var (
+	// ErrInvalidSeed indicates a seed of invalid length, or one deriving an invalid master key
+	ErrInvalidSeed = errors.New("invalid seed")
+	// ErrInvalidKey indicates a malformed extended key
+	ErrInvalidKey = errors.New("invalid extended key")
+	// ErrInvalidChild indicates a child index deriving an invalid key, the next index should be used instead
+	ErrInvalidChild = errors.New("invalid child key")
+	// ErrHardenedFromPublic indicates a hardened child derived from a public key
+	ErrHardenedFromPublic = errors.New("cannot derive a hardened child from a public key")
+	// ErrMaxDepth indicates a child deeper than 255 levels
+	ErrMaxDepth = errors.New("cannot derive a key deeper than 255 levels")
+)
+
+// ExtendedKey is a BIP-32 extended private or public key
+type ExtendedKey struct {
+	version   [4]byte
+	depth     uint8
+	parentFP  [4]byte
+	childNum  uint32
+	chainCode [32]byte
+	// key is the 32-byte private key for a private extended key, or the 33-byte compressed public key otherwise
+	key     []byte
+	private bool
+}
+
+// NewMaster derives the master extended private key from the seed
+func NewMaster(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < MinSeedLength || len(seed) > MaxSeedLength {
+		return nil, errors.Wrapf(ErrInvalidSeed, "seed length = %d, expecting %d to %d", len(seed), MinSeedLength,
+			MaxSeedLength)
+	}
+	mac := hmac.New(sha512.New, masterKey)
+	mac.Write(seed)
+	i := mac.Sum(nil)
+	var k secp256k1.ModNScalar
+	if overflow := k.SetByteSlice(i[:32]); overflow || k.IsZero() {
+		return nil, errors.Wrap(ErrInvalidSeed, "master key out of range")
+	}
+	key := ExtendedKey{
+		version: MainnetPrivateVersion,
+		key:     i[:32],
+		private: true,
+	}
+	copy(key.chainCode[:], i[32:])
+	return &key, nil
+}
+
+// IsPrivate returns true for an extended private key
+func (k *ExtendedKey) IsPrivate() bool { return k.private }
+
+// Depth returns the depth of the key, which is 0 for the master key
+func (k *ExtendedKey) Depth() uint8 { return k.depth }
+
+// ChildNumber returns the index the key was derived with, which is 0 for the master key
+func (k *ExtendedKey) ChildNumber() uint32 { return k.childNum }
+
+// ChainCode returns the 32-byte chain code
+func (k *ExtendedKey) ChainCode() []byte { return append([]byte{}, k.chainCode[:]...) }
+
+// ParentFingerprint returns the fingerprint of the parent key, which is 0 for the master key
+func (k *ExtendedKey) ParentFingerprint() uint32 { return binary.BigEndian.Uint32(k.parentFP[:]) }
+
+// PrivateKey returns the 32-byte private key, or ErrInvalidKey for an extended public key
+func (k *ExtendedKey) PrivateKey() ([]byte, error) {
+	if !k.private {
+		return nil, errors.Wrap(ErrInvalidKey, "not a private key")
+	}
+	return append([]byte{}, k.key...), nil
+}
+
+// PublicKey returns the 33-byte compressed public key
+func (k *ExtendedKey) PublicKey() []byte {
+	if !k.private {
+		return append([]byte{}, k.key...)
+	}
+	return secp256k1.PrivKeyFromBytes(k.key).PubKey().SerializeCompressed()
+}
+
+// Address returns the address of the public key
+func (k *ExtendedKey) Address() (address.Address, error) {
+	return address.FromPublicKey(k.PublicKey())
+}
+
+// Fingerprint returns the first 4 bytes of the hash160 of the public key
+func (k *ExtendedKey) Fingerprint() uint32 {
+	return binary.BigEndian.Uint32(hash160(k.PublicKey())[:4])
+}
+
+// Neuter returns the extended public key of the key
+func (k *ExtendedKey) Neuter() *ExtendedKey {
+	if !k.private {
+		return k
+	}
+	pub := *k
+	pub.key = k.PublicKey()
+	pub.private = false
+	switch k.version {
+	case MainnetPrivateVersion:
+		pub.version = MainnetPublicVersion
+	case TestnetPrivateVersion:
+		pub.version = TestnetPublicVersion
+	}
+	return &pub
+}
+
+// Child derives the child key of the index, which is hardened if it is at least HardenedOffset
+// A public key derives public children of non-hardened indexes only
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	if k.depth == 255 {
+		return nil, ErrMaxDepth
+	}
+	hardened := index >= HardenedOffset
+	if hardened && !k.private {
+		return nil, ErrHardenedFromPublic
+	}
+	// the data is 0x00 || private key for a hardened child, or the compressed public key otherwise
+	data := make([]byte, 0, 33+4)
+	if hardened {
+		data = append(data, 0)
+		data = append(data, k.key...)
+	} else {
+		data = append(data, k.PublicKey()...)
+	}
+	data = append(data, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(data[33:], index)
+	mac := hmac.New(sha512.New, k.chainCode[:])
+	mac.Write(data)
+	i := mac.Sum(nil)
+
+	var il secp256k1.ModNScalar
+	if overflow := il.SetByteSlice(i[:32]); overflow {
+		return nil, errors.Wrapf(ErrInvalidChild, "index %d", index)
+	}
+	child := ExtendedKey{
+		version:  k.version,
+		depth:    k.depth + 1,
+		childNum: index,
+		private:  k.private,
+	}
+	binary.BigEndian.PutUint32(child.parentFP[:], k.Fingerprint())
+	copy(child.chainCode[:], i[32:])
+	if k.private {
+		// child key = IL + parent key (mod n)
+		var parent secp256k1.ModNScalar
+		parent.SetByteSlice(k.key)
+		il.Add(&parent)
+		if il.IsZero() {
+			return nil, errors.Wrapf(ErrInvalidChild, "index %d", index)
+		}
+		b := il.Bytes()
+		child.key = b[:]
+		return &child, nil
+	}
+	// child key = IL*G + parent key
+	parent, err := secp256k1.ParsePubKey(k.key)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidKey, err.Error())
+	}
+	var p, q, sum secp256k1.JacobianPoint
+	secp256k1.ScalarBaseMultNonConst(&il, &p)
+	parent.AsJacobian(&q)
+	secp256k1.AddNonConst(&p, &q, &sum)
+	if (sum.X.IsZero() && sum.Y.IsZero()) || sum.Z.IsZero() {
+		return nil, errors.Wrapf(ErrInvalidChild, "index %d", index)
+	}
+	sum.ToAffine()
+	child.key = secp256k1.NewPublicKey(&sum.X, &sum.Y).SerializeCompressed()
+	return &child, nil
+}
+
+// Derive derives the descendant key along the path relative to the key
+func (k *ExtendedKey) Derive(path Path) (*ExtendedKey, error) {
+	key := k
+	for _, index := range path {
+		var err error
+		if key, err = key.Child(index); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// String serializes the key into the base58 form, e.g., xprv... or xpub...
+func (k *ExtendedKey) String() string {
+	b := make([]byte, 0, serializedKeyLength)
+	b = append(b, k.version[:]...)
+	b = append(b, k.depth)
+	b = append(b, k.parentFP[:]...)
+	b = append(b, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(b[9:], k.childNum)
+	b = append(b, k.chainCode[:]...)
+	if k.private {
+		b = append(b, 0)
+	}
+	b = append(b, k.key...)
+	return base58CheckEncode(b)
+}
+
+// ParseExtendedKey parses a serialized extended key of mainnet or testnet
+func ParseExtendedKey(s string) (*ExtendedKey, error) {
+	b, err := base58CheckDecode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != serializedKeyLength {
+		return nil, errors.Wrapf(ErrInvalidKey, "length = %d, expecting %d", len(b), serializedKeyLength)
+	}
+	k := ExtendedKey{depth: b[4], childNum: binary.BigEndian.Uint32(b[9:13])}
+	copy(k.version[:], b[:4])
+	copy(k.parentFP[:], b[5:9])
+	copy(k.chainCode[:], b[13:45])
+	switch k.version {
+	case MainnetPrivateVersion, TestnetPrivateVersion:
+		k.private = true
+	case MainnetPublicVersion, TestnetPublicVersion:
+	default:
+		return nil, errors.Wrapf(ErrInvalidKey, "unknown version %x", k.version)
+	}
+	if k.depth == 0 && (k.parentFP != [4]byte{} || k.childNum != 0) {
+		return nil, errors.Wrap(ErrInvalidKey, "master key with parent")
+	}
+	key := b[45:]
+	if k.private {
+		var scalar secp256k1.ModNScalar
+		if key[0] != 0 {
+			return nil, errors.Wrap(ErrInvalidKey, "private key not prefixed with 0x00")
+		}
+		if overflow := scalar.SetByteSlice(key[1:]); overflow || scalar.IsZero() {
+			return nil, errors.Wrap(ErrInvalidKey, "private key out of range")
+		}
+		k.key = append([]byte{}, key[1:]...)
+		return &k, nil
+	}
+	if key[0] != 0x02 && key[0] != 0x03 {
+		return nil, errors.Wrap(ErrInvalidKey, "public key not compressed")
+	}
+	if _, err := secp256k1.ParsePubKey(key); err != nil {
+		return nil, errors.Wrap(ErrInvalidKey, err.Error())
+	}
+	k.key = append([]byte{}, key...)
+	return &k, nil
+}
+
+// Equal returns true if the keys serialize to the same string
+func (k *ExtendedKey) Equal(other *ExtendedKey) bool {
+	return k.private == other.private && k.version == other.version && k.depth == other.depth &&
+		k.parentFP == other.parentFP && k.childNum == other.childNum && k.chainCode == other.chainCode &&
+		bytes.Equal(k.key, other.key)
+}
+
+// hash160 returns RIPEMD-160(SHA-256(b)), as used for key fingerprints
+func hash160(b []byte) []byte {
+	h := sha256.Sum256(b)
+	r := ripemd160.New()
+	r.Write(h[:])
+	return r.Sum(nil)
+}
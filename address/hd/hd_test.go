@@ -0,0 +1,214 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package hd
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+// test vectors from https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki
+var bip32Vectors = []struct {
+	seed string
+	keys []struct{ path, xpub, xprv string }
+}{
+	{
+		"000102030405060708090a0b0c0d0e0f",
+		[]struct{ path, xpub, xprv string }{
+			{"m",
+				"xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+				"xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi"},
+			{"m/0H",
+				"xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw",
+				"xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7"},
+			{"m/0H/1",
+				"xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ",
+				"xprv9wTYmMFdV23N2TdNG573QoEsfRrWKQgWeibmLntzniatZvR9BmLnvSxqu53Kw1UmYPxLgboyZQaXwTCg8MSY3H2EU4pWcQDnRnrVA1xe8fs"},
+			{"m/0H/1/2H",
+				"xpub6D4BDPcP2GT577Vvch3R8wDkScZWzQzMMUm3PWbmWvVJrZwQY4VUNgqFJPMM3No2dFDFGTsxxpG5uJh7n7epu4trkrX7x7DogT5Uv6fcLW5",
+				"xprv9z4pot5VBttmtdRTWfWQmoH1taj2axGVzFqSb8C9xaxKymcFzXBDptWmT7FwuEzG3ryjH4ktypQSAewRiNMjANTtpgP4mLTj34bhnZX7UiM"},
+			{"m/0H/1/2H/2",
+				"xpub6FHa3pjLCk84BayeJxFW2SP4XRrFd1JYnxeLeU8EqN3vDfZmbqBqaGJAyiLjTAwm6ZLRQUMv1ZACTj37sR62cfN7fe5JnJ7dh8zL4fiyLHV",
+				"xprvA2JDeKCSNNZky6uBCviVfJSKyQ1mDYahRjijr5idH2WwLsEd4Hsb2Tyh8RfQMuPh7f7RtyzTtdrbdqqsunu5Mm3wDvUAKRHSC34sJ7in334"},
+			{"m/0H/1/2H/2/1000000000",
+				"xpub6H1LXWLaKsWFhvm6RVpEL9P4KfRZSW7abD2ttkWP3SSQvnyA8FSVqNTEcYFgJS2UaFcxupHiYkro49S8yGasTvXEYBVPamhGW6cFJodrTHy",
+				"xprvA41z7zogVVwxVSgdKUHDy1SKmdb533PjDz7J6N6mV6uS3ze1ai8FHa8kmHScGpWmj4WggLyQjgPie1rFSruoUihUZREPSL39UNdE3BBDu76"},
+		},
+	},
+	{
+		"fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542",
+		[]struct{ path, xpub, xprv string }{
+			{"m",
+				"xpub661MyMwAqRbcFW31YEwpkMuc5THy2PSt5bDMsktWQcFF8syAmRUapSCGu8ED9W6oDMSgv6Zz8idoc4a6mr8BDzTJY47LJhkJ8UB7WEGuduB",
+				"xprv9s21ZrQH143K31xYSDQpPDxsXRTUcvj2iNHm5NUtrGiGG5e2DtALGdso3pGz6ssrdK4PFmM8NSpSBHNqPqm55Qn3LqFtT2emdEXVYsCzC2U"},
+			{"m/0",
+				"xpub69H7F5d8KSRgmmdJg2KhpAK8SR3DjMwAdkxj3ZuxV27CprR9LgpeyGmXUbC6wb7ERfvrnKZjXoUmmDznezpbZb7ap6r1D3tgFxHmwMkQTPH",
+				"xprv9vHkqa6EV4sPZHYqZznhT2NPtPCjKuDKGY38FBWLvgaDx45zo9WQRUT3dKYnjwih2yJD9mkrocEZXo1ex8G81dwSM1fwqWpWkeS3v86pgKt"},
+		},
+	},
+	{
+		// leading zeros are retained
+		"4b381541583be4423346c643850da4b320e46a87ae3d2a4e6da11eba819cd4acba45d239319ac14f863b8d5ab5a0d0c64d2e8a1e7d1457df2e5a3c51c73235be",
+		[]struct{ path, xpub, xprv string }{
+			{"m",
+				"xpub661MyMwAqRbcEZVB4dScxMAdx6d4nFc9nvyvH3v4gJL378CSRZiYmhRoP7mBy6gSPSCYk6SzXPTf3ND1cZAceL7SfJ1Z3GC8vBgp2epUt13",
+				"xprv9s21ZrQH143K25QhxbucbDDuQ4naNntJRi4KUfWT7xo4EKsHt2QJDu7KXp1A3u7Bi1j8ph3EGsZ9Xvz9dGuVrtHHs7pXeTzjuxBrCmmhgC6"},
+			{"m/0H",
+				"xpub68NZiKmJWnxxS6aaHmn81bvJeTESw724CRDs6HbuccFQN9Ku14VQrADWgqbhhTHBaohPX4CjNLf9fq9MYo6oDaPPLPxSb7gwQN3ih19Zm4Y",
+				"xprv9uPDJpEQgRQfDcW7BkF7eTya6RPxXeJCqCJGHuCJ4GiRVLzkTXBAJMu2qaMWPrS7AANYqdq6vcBcBUdJCVVFceUvJFjaPdGZ2y9WACViL4L"},
+		},
+	},
+}
+
+func TestBIP32Vectors(t *testing.T) {
+	r := require.New(t)
+
+	for _, v := range bip32Vectors {
+		seed, err := hex.DecodeString(v.seed)
+		r.NoError(err)
+		master, err := NewMaster(seed)
+		r.NoError(err)
+		for _, k := range v.keys {
+			path, err := ParsePath(k.path)
+			r.NoError(err)
+			key, err := master.Derive(path)
+			r.NoError(err)
+			r.Equal(k.xprv, key.String(), k.path)
+			r.Equal(k.xpub, key.Neuter().String(), k.path)
+
+			// serialization round-trips
+			parsed, err := ParseExtendedKey(k.xprv)
+			r.NoError(err)
+			r.True(parsed.Equal(key))
+			parsed, err = ParseExtendedKey(k.xpub)
+			r.NoError(err)
+			r.True(parsed.Equal(key.Neuter()))
+			r.False(parsed.IsPrivate())
+		}
+	}
+}
+
+func TestIoTeXDerivation(t *testing.T) {
+	r := require.New(t)
+
+	seed, err := hex.DecodeString(bip32Vectors[0].seed)
+	r.NoError(err)
+	master, err := NewMaster(seed)
+	r.NoError(err)
+	path := IoTeXPath(0, 0, 0)
+	r.Equal("m/44'/304'/0'/0/0", path.String())
+
+	// known answers of m/44'/304'/0'/0/i
+	const accountXpub = "xpub6EgMGG4A7PZdwFcQUUY8sZByzoLk58TdN6NmCAHdX68aEh325oxSQsNcLAcYL6uKQJ2PjrTNjUHXbof73UCMdN5ezVXxY4tXAwpD3jEKUP8"
+	expected := []struct {
+		addr, privateKey string
+	}{
+		{"io1qg3wgvdrh0caz3vra5ptppw4x7e4t2sw5jvglc", "02b26c4c27e4b412ce1460ecf021cb5043e59c7d85fb53144d8bc34a6dbb7020"},
+		{"io1frn0p9xdy28xgp2ljk43u3ug2f2f0tl7ngn8cd", "1863476340f53ff378040367c00102011b8c4baa157aef7a660b5170683c6522"},
+		{"io16up5fz5d70cw38m9v3ymv0fk777py4l3fllx02", "b3d592f2af2b3ca6220c10809fc300675942e68ec66d05964ad35338b1c0a18a"},
+	}
+	account, err := master.Derive(path[:4])
+	r.NoError(err)
+	r.Equal(accountXpub, account.Neuter().String())
+	for i, v := range expected {
+		key, err := master.Derive(IoTeXPath(0, 0, uint32(i)))
+		r.NoError(err)
+		addr, err := key.Address()
+		r.NoError(err)
+		r.Equal(v.addr, addr.String())
+		pk, err := key.PrivateKey()
+		r.NoError(err)
+		r.Equal(v.privateKey, hex.EncodeToString(pk))
+	}
+
+	// a watch-only server derives the same addresses from the account xpub
+	watch, err := ParseExtendedKey(accountXpub)
+	r.NoError(err)
+	addrs, err := DeriveAddresses(watch, 0, uint32(len(expected)))
+	r.NoError(err)
+	for i, v := range expected {
+		r.Equal(v.addr, addrs[i].String())
+	}
+	addrs, err = DeriveAddresses(account, 1, 2)
+	r.NoError(err)
+	r.Len(addrs, 2)
+	r.True(address.Equal(addrs[1], mustAddress(r, expected[2].addr)))
+
+	// the range must neither overflow nor reach the hardened indexes
+	addrs, err = DeriveAddresses(watch, HardenedOffset-2, 2)
+	r.NoError(err)
+	r.Len(addrs, 2)
+	for _, v := range []struct{ start, count uint32 }{
+		{HardenedOffset - 2, 3},
+		{HardenedOffset, 0},
+		{0, HardenedOffset + 1},
+		{math.MaxUint32, 2},
+	} {
+		addrs, err = DeriveAddresses(watch, v.start, v.count)
+		r.True(errors.Is(err, ErrInvalidPath), v)
+		r.Nil(addrs)
+	}
+
+	// public keys cannot derive hardened children or reveal the private key
+	_, err = watch.Child(HardenedOffset)
+	r.Equal(ErrHardenedFromPublic, err)
+	_, err = watch.PrivateKey()
+	r.True(errors.Is(err, ErrInvalidKey))
+	r.Equal(watch, watch.Neuter())
+	r.Equal(uint8(4), watch.Depth())
+	r.Equal(uint32(0), watch.ChildNumber())
+	r.Equal(account.ChainCode(), watch.ChainCode())
+}
+
+func mustAddress(r *require.Assertions, s string) address.Address {
+	addr, err := address.FromString(s)
+	r.NoError(err)
+	return addr
+}
+
+func TestErrors(t *testing.T) {
+	r := require.New(t)
+
+	for _, n := range []int{MinSeedLength - 1, MaxSeedLength + 1} {
+		_, err := NewMaster(make([]byte, n))
+		r.True(errors.Is(err, ErrInvalidSeed))
+	}
+
+	xprv := bip32Vectors[0].keys[1].xprv
+	for _, s := range []string{
+		"",
+		"xprv",
+		xprv[:len(xprv)-1] + "1",
+		xprv[:10] + "0" + xprv[11:],
+		// master key with parent fingerprint or child number
+		"xpub661no6RGEX3uJkY4bNnPcw4URcQTrSibUZ4NqJEw5eBkv7ovTwgiT91XX27VbEXGENhYRCf7hyEbWrR3FewATdCEebj6znwMfQkhRYHRLpJ",
+		"xpub661MyMwAuDcm6CRQ5N4qiHKrJ39Xe1R1NyfouMKTTWcguwVcfrZJaNvhpebzGerh7gucBvzEQWRugZDuDXjNDRmXzSZe4c7mnTK97pTvGS8",
+		// invalid public key prefix
+		"xpub661MyMwAqRbcEYS8w7XLSVeEsBXy79zSzH1J8vCdxAZningWLdN3zgtU6Txnt3siSujt9RCVYsx4qHZGc62TG4McvMGcAUjeuwZdduYEvFn",
+	} {
+		_, err := ParseExtendedKey(s)
+		r.True(errors.Is(err, ErrInvalidKey), s)
+	}
+
+	for _, s := range []string{"", "44'/0", "m/", "m/x", "m/-1", "m/01", "m/2147483648", "m/1''"} {
+		_, err := ParsePath(s)
+		r.True(errors.Is(err, ErrInvalidPath), s)
+	}
+	path, err := ParsePath("m/44h/304H/0'/1/2")
+	r.NoError(err)
+	r.Equal(Path{44 + HardenedOffset, 304 + HardenedOffset, HardenedOffset, 1, 2}, path)
+	r.Equal("m/44'/304'/0'/1/2", path.String())
+	path, err = ParsePath("m")
+	r.NoError(err)
+	r.Empty(path)
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package hd
+
+import (
+	"github.com/iotexproject/iotex-address/address/bip39"
+)
+
+// NewMasterFromMnemonic validates the BIP-39 mnemonic, and derives the master extended private key from its seed and
+// the passphrase, which may be empty
+func NewMasterFromMnemonic(mnemonic, passphrase string) (*ExtendedKey, error) {
+	seed, err := bip39.NewSeedWithChecksum(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return NewMaster(seed)
+}
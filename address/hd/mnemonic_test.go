@@ -0,0 +1,46 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package hd
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-address/address/bip39"
+)
+
+func TestNewMasterFromMnemonic(t *testing.T) {
+	r := require.New(t)
+
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	master, err := NewMasterFromMnemonic(mnemonic, "")
+	r.NoError(err)
+
+	// the well-known first Ethereum account of the mnemonic
+	path, err := ParsePath("m/44'/60'/0'/0/0")
+	r.NoError(err)
+	key, err := master.Derive(path)
+	r.NoError(err)
+	addr, err := key.Address()
+	r.NoError(err)
+	r.Equal("0x9858EfFD232B4033E47d90003D41EC34EcaEda94", addr.(*address.AddrV1).ChecksumHex())
+
+	// the passphrase derives another wallet
+	other, err := NewMasterFromMnemonic(mnemonic, "TREZOR")
+	r.NoError(err)
+	r.False(master.Equal(other))
+	expected, err := NewMaster(bip39.NewSeed(mnemonic, "TREZOR"))
+	r.NoError(err)
+	r.True(expected.Equal(other))
+
+	_, err = NewMasterFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon "+
+		"abandon abandon", "")
+	r.True(errors.Is(err, bip39.ErrInvalidMnemonic))
+}
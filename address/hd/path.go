@@ -0,0 +1,103 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package hd
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+const (
+	// HardenedOffset is the first index of hardened children
+	HardenedOffset uint32 = 0x80000000
+	// Purpose is the BIP-44 purpose
+	Purpose uint32 = 44
+	// CoinType is the registered BIP-44 coin type of IoTeX
+	CoinType uint32 = 304
+)
+
+// ErrInvalidPath indicates a malformed derivation path, or indexes out of range
+var ErrInvalidPath = errors.New("invalid derivation path")
+
+// maxPreallocatedAddresses bounds the capacity DeriveAddresses allocates up front
+const maxPreallocatedAddresses = 1024
+
+// Path is a derivation path of child indexes, hardened indexes being at least HardenedOffset
+type Path []uint32
+
+// IoTeXPath returns the BIP-44 path m/44'/304'/account'/change/index
+func IoTeXPath(account, change, index uint32) Path {
+	return Path{Purpose + HardenedOffset, CoinType + HardenedOffset, account + HardenedOffset, change, index}
+}
+
+// ParsePath parses a path such as m/44'/304'/0'/0/0, in which a hardened index is marked with ' or h
+func ParsePath(s string) (Path, error) {
+	parts := strings.Split(s, "/")
+	if parts[0] != "m" {
+		return nil, errors.Wrapf(ErrInvalidPath, "%s does not start with m", s)
+	}
+	path := make(Path, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		var offset uint32
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") || strings.HasSuffix(part, "H") {
+			part, offset = part[:len(part)-1], HardenedOffset
+		}
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(index) >= HardenedOffset || part != strconv.FormatUint(index, 10) {
+			return nil, errors.Wrapf(ErrInvalidPath, "invalid index %s in %s", part, s)
+		}
+		path = append(path, uint32(index)+offset)
+	}
+	return path, nil
+}
+
+// String returns the path in the form of m/44'/304'/0'/0/0
+func (p Path) String() string {
+	var b strings.Builder
+	b.WriteByte('m')
+	for _, index := range p {
+		b.WriteByte('/')
+		if index >= HardenedOffset {
+			b.WriteString(strconv.FormatUint(uint64(index-HardenedOffset), 10))
+			b.WriteByte('\'')
+			continue
+		}
+		b.WriteString(strconv.FormatUint(uint64(index), 10))
+	}
+	return b.String()
+}
+
+// DeriveAddresses derives the addresses of count consecutive indexes from start, of the account and change level
+// key, e.g., the key of m/44'/304'/0'/0, which may be an extended public key of a watch-only server
+// The indexes must be non-hardened, i.e., start+count must not exceed HardenedOffset
+func DeriveAddresses(key *ExtendedKey, start, count uint32) ([]address.Address, error) {
+	if start >= HardenedOffset || count > HardenedOffset-start {
+		return nil, errors.Wrapf(ErrInvalidPath, "%d indexes from %d exceed the non-hardened indexes", count, start)
+	}
+	// the addresses are appended as they are derived, rather than allocated up front for a count of up to 2^31
+	capacity := count
+	if capacity > maxPreallocatedAddresses {
+		capacity = maxPreallocatedAddresses
+	}
+	addrs := make([]address.Address, 0, capacity)
+	for i := start; i < start+count; i++ {
+		child, err := key.Child(i)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := child.Address()
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package keystore
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+var (
+	// ErrInvalidKeystore indicates a malformed or unsupported keystore file
+	ErrInvalidKeystore = errors.New("invalid keystore")
+	// ErrDecrypt indicates a wrong password, or a corrupted file, of which the MAC does not match
+	ErrDecrypt = errors.New("could not decrypt key with given password")
+	// ErrInvalidKey indicates an invalid secp256k1 private key
+	ErrInvalidKey = errors.New("invalid private key")
+)
+
+// AddressError reports a keystore file whose address field is not the address of its key
+type AddressError struct {
+	// Got is the address in the file
+	Got *address.AddrV1
+	// Want is the address derived from the decrypted key
+	Want *address.AddrV1
+}
+
+// Error returns the error message, naming both addresses in hex and bech32
+func (e *AddressError) Error() string {
+	return fmt.Sprintf("address %s (%s) does not match key address %s (%s): %v", e.Got.ChecksumHex(), e.Got,
+		e.Want.ChecksumHex(), e.Want, ErrInvalidKeystore)
+}
+
+// Unwrap returns ErrInvalidKeystore
+func (e *AddressError) Unwrap() error { return ErrInvalidKeystore }
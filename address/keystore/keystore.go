@@ -0,0 +1,375 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package keystore encrypts and decrypts secp256k1 private keys in Web3 Secret Storage V3 JSON files, as used by
+// ioctl, geth and MetaMask, and ties each file to the IoTeX address of its key
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v3"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+const (
+	// Version is the version of the keystore file format
+	Version = 3
+	// KDFScrypt is the scrypt key derivation function
+	KDFScrypt = "scrypt"
+	// KDFPBKDF2 is the PBKDF2 key derivation function with HMAC-SHA256
+	KDFPBKDF2 = "pbkdf2"
+	// cipherName is the cipher of the private key
+	cipherName = "aes-128-ctr"
+	// prfName is the pseudorandom function of PBKDF2
+	prfName = "hmac-sha256"
+	// keyLength is the derived key length, of which the first half is the cipher key and the second half the MAC key
+	keyLength = 32
+	// privateKeyLength is the byte length of a secp256k1 private key
+	privateKeyLength = 32
+)
+
+// bounds of the key derivation parameters, so that a crafted file cannot exhaust memory or CPU, at 4 times the
+// memory and 16 times the work of StandardOptions
+const (
+	// maxScryptMemory bounds the memory of scrypt, which is 128*N*r bytes
+	maxScryptMemory = 1 << 30
+	// maxScryptCost bounds the work of scrypt, which is proportional to N*r*p
+	maxScryptCost = 1 << 25
+	// maxPBKDF2Iterations bounds the iteration count of PBKDF2
+	maxPBKDF2Iterations = 1 << 22
+)
+
+// Options are the key derivation parameters to encrypt a key with
+type Options struct {
+	// KDF is KDFScrypt or KDFPBKDF2
+	KDF string
+	// ScryptN is the CPU and memory cost of scrypt, a power of 2
+	ScryptN int
+	// ScryptR is the block size of scrypt
+	ScryptR int
+	// ScryptP is the parallelization of scrypt
+	ScryptP int
+	// Iterations is the iteration count of PBKDF2
+	Iterations int
+}
+
+var (
+	// StandardOptions are the scrypt parameters of geth and ioctl, taking about a second and 256MB memory
+	StandardOptions = Options{KDF: KDFScrypt, ScryptN: 1 << 18, ScryptR: 8, ScryptP: 1}
+	// LightOptions are the scrypt parameters of geth for devices of limited memory
+	LightOptions = Options{KDF: KDFScrypt, ScryptN: 1 << 12, ScryptR: 8, ScryptP: 6}
+	// PBKDF2Options are the PBKDF2 parameters of the V3 test vectors
+	PBKDF2Options = Options{KDF: KDFPBKDF2, Iterations: 1 << 18}
+)
+
+type (
+	// Key is a decrypted private key and its address
+	Key struct {
+		// ID is the UUID of the keystore file
+		ID string
+		// PrivateKey is the 32-byte private key
+		PrivateKey []byte
+		// Address is the address of the private key
+		Address *address.AddrV1
+	}
+
+	// file is the V3 JSON file
+	file struct {
+		Address string     `json:"address,omitempty"`
+		Crypto  cryptoJSON `json:"crypto"`
+		ID      string     `json:"id"`
+		Version int        `json:"version"`
+	}
+
+	cryptoJSON struct {
+		Cipher       string           `json:"cipher"`
+		CipherText   string           `json:"ciphertext"`
+		CipherParams cipherParamsJSON `json:"cipherparams"`
+		KDF          string           `json:"kdf"`
+		KDFParams    kdfParamsJSON    `json:"kdfparams"`
+		MAC          string           `json:"mac"`
+	}
+
+	cipherParamsJSON struct {
+		IV string `json:"iv"`
+	}
+
+	// kdfParamsJSON holds the parameters of either KDF
+	kdfParamsJSON struct {
+		DKLen int    `json:"dklen"`
+		Salt  string `json:"salt"`
+		N     int    `json:"n,omitempty"`
+		R     int    `json:"r,omitempty"`
+		P     int    `json:"p,omitempty"`
+		C     int    `json:"c,omitempty"`
+		PRF   string `json:"prf,omitempty"`
+	}
+)
+
+// Hex returns the EIP-55 checksummed hex address, e.g., for MetaMask
+func (k *Key) Hex() string { return k.Address.ChecksumHex() }
+
+// String returns the bech32 address, e.g., io1...
+func (k *Key) String() string { return k.Address.String() }
+
+// NewKey returns the key of the 32-byte private key with a random ID
+func NewKey(privateKey []byte) (*Key, error) {
+	addr, err := addressOf(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	id, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+	return &Key{ID: id, PrivateKey: append([]byte{}, privateKey...), Address: addr}, nil
+}
+
+// Encrypt encrypts the key with the password into a V3 JSON file, whose address field is the lowercase hex address
+// without "0x" as written by geth
+// The address is derived from the private key, so that the Address of the key is not needed
+func Encrypt(key *Key, password string, opts Options) ([]byte, error) {
+	addr, err := addressOf(key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	salt, iv := make([]byte, 32), make([]byte, aes.BlockSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "failed to read random bytes")
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return nil, errors.Wrap(err, "failed to read random bytes")
+	}
+	params := kdfParamsJSON{DKLen: keyLength, Salt: hex.EncodeToString(salt)}
+	switch opts.KDF {
+	case KDFScrypt:
+		params.N, params.R, params.P = opts.ScryptN, opts.ScryptR, opts.ScryptP
+	case KDFPBKDF2:
+		params.C, params.PRF = opts.Iterations, prfName
+	}
+	dk, err := deriveKey(opts.KDF, &params, password)
+	if err != nil {
+		return nil, err
+	}
+	cipherText, err := aesCTR(dk[:16], iv, key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	f := file{
+		Address: strings.TrimPrefix(addr.Hex(), "0x"),
+		Crypto: cryptoJSON{
+			Cipher:       cipherName,
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: cipherParamsJSON{IV: hex.EncodeToString(iv)},
+			KDF:          opts.KDF,
+			KDFParams:    params,
+			MAC:          hex.EncodeToString(keccak256(dk[16:32], cipherText)),
+		},
+		ID:      key.ID,
+		Version: Version,
+	}
+	return json.Marshal(&f)
+}
+
+// Decrypt decrypts the V3 JSON file with the password
+// The address field, if present, must be the address of the decrypted key, either in hex with or without "0x", or in
+// bech32, otherwise an *AddressError reports both
+func Decrypt(keyJSON []byte, password string) (*Key, error) {
+	var f file
+	if err := json.Unmarshal(keyJSON, &f); err != nil {
+		return nil, errors.Wrap(ErrInvalidKeystore, err.Error())
+	}
+	if f.Version != Version {
+		return nil, errors.Wrapf(ErrInvalidKeystore, "version = %d, expecting %d", f.Version, Version)
+	}
+	if f.Crypto.Cipher != cipherName {
+		return nil, errors.Wrapf(ErrInvalidKeystore, "unsupported cipher %q", f.Crypto.Cipher)
+	}
+	mac, err := decodeHex("mac", f.Crypto.MAC)
+	if err != nil {
+		return nil, err
+	}
+	iv, err := decodeHex("iv", f.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, err
+	}
+	cipherText, err := decodeHex("ciphertext", f.Crypto.CipherText)
+	if err != nil {
+		return nil, err
+	}
+	dk, err := deriveKey(f.Crypto.KDF, &f.Crypto.KDFParams, password)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(keccak256(dk[16:32], cipherText), mac) {
+		return nil, ErrDecrypt
+	}
+	privateKey, err := aesCTR(dk[:16], iv, cipherText)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := addressOf(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if f.Address != "" {
+		stated, err := parseAddress(f.Address)
+		if err != nil {
+			return nil, err
+		}
+		if !address.Equal(stated, addr) {
+			return nil, &AddressError{Got: stated, Want: addr}
+		}
+	}
+	return &Key{ID: f.ID, PrivateKey: privateKey, Address: addr}, nil
+}
+
+// deriveKey derives the 32-byte key from the password, of which dklen must be 32
+// The parameters are bounded by maxScryptMemory, maxScryptCost and maxPBKDF2Iterations
+func deriveKey(kdf string, params *kdfParamsJSON, password string) ([]byte, error) {
+	if params.DKLen != keyLength {
+		return nil, errors.Wrapf(ErrInvalidKeystore, "dklen = %d, expecting %d", params.DKLen, keyLength)
+	}
+	salt, err := decodeHex("salt", params.Salt)
+	if err != nil {
+		return nil, err
+	}
+	switch kdf {
+	case KDFScrypt:
+		if err := checkScryptParams(params.N, params.R, params.P); err != nil {
+			return nil, err
+		}
+		dk, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, keyLength)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidKeystore, err.Error())
+		}
+		return dk, nil
+	case KDFPBKDF2:
+		if params.PRF != prfName {
+			return nil, errors.Wrapf(ErrInvalidKeystore, "unsupported prf %q", params.PRF)
+		}
+		if params.C <= 0 || params.C > maxPBKDF2Iterations {
+			return nil, errors.Wrapf(ErrInvalidKeystore, "iteration count = %d, expecting 1 to %d", params.C,
+				maxPBKDF2Iterations)
+		}
+		return pbkdf2.Key([]byte(password), salt, params.C, keyLength, sha256.New), nil
+	default:
+		return nil, errors.Wrapf(ErrInvalidKeystore, "unsupported kdf %q", kdf)
+	}
+}
+
+// checkScryptParams checks that n is a power of 2 greater than 1, and that r and p are positive, within the bounds
+// of memory and work
+func checkScryptParams(n, r, p int) error {
+	if n <= 1 || n&(n-1) != 0 || n > maxScryptMemory/128 {
+		return errors.Wrapf(ErrInvalidKeystore, "scrypt n = %d, expecting a power of 2 from 2 to %d", n,
+			maxScryptMemory/128)
+	}
+	if r <= 0 || r > maxScryptMemory/(128*n) {
+		return errors.Wrapf(ErrInvalidKeystore, "scrypt r = %d, expecting 1 to %d for n = %d", r,
+			maxScryptMemory/(128*n), n)
+	}
+	if p <= 0 || p > maxScryptCost/(n*r) {
+		return errors.Wrapf(ErrInvalidKeystore, "scrypt p = %d, expecting 1 to %d for n = %d and r = %d", p,
+			maxScryptCost/(n*r), n, r)
+	}
+	return nil
+}
+
+func aesCTR(key, iv, in []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidKeystore, err.Error())
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, errors.Wrapf(ErrInvalidKeystore, "iv length = %d, expecting %d", len(iv), aes.BlockSize)
+	}
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
+
+// addressOf derives the address of the private key, which must be in [1, N-1]
+func addressOf(privateKey []byte) (*address.AddrV1, error) {
+	if len(privateKey) != privateKeyLength {
+		return nil, errors.Wrapf(ErrInvalidKey, "private key length = %d, expecting %d", len(privateKey),
+			privateKeyLength)
+	}
+	var k secp256k1.ModNScalar
+	if overflow := k.SetByteSlice(privateKey); overflow || k.IsZero() {
+		return nil, errors.Wrap(ErrInvalidKey, "private key out of range")
+	}
+	addr, err := address.FromPublicKey(secp256k1.PrivKeyFromBytes(privateKey).PubKey().SerializeCompressed())
+	if err != nil {
+		return nil, err
+	}
+	return addr.(*address.AddrV1), nil
+}
+
+// parseAddress parses the address field, which is hex with or without "0x", or bech32
+func parseAddress(s string) (*address.AddrV1, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+	}
+	var (
+		addr address.Address
+		err  error
+	)
+	if len(s) == 40 {
+		addr, err = address.FromHex(s)
+	} else {
+		addr, err = address.FromString(s)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidKeystore, "malformed address %q", s)
+	}
+	v1, err := address.ToV1(addr)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidKeystore, "malformed address %q", s)
+	}
+	return v1, nil
+}
+
+func decodeHex(name, s string) ([]byte, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidKeystore, "malformed %s: %v", name, err)
+	}
+	return b, nil
+}
+
+// newUUID returns a random version 4 UUID
+func newUUID() (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		return "", errors.Wrap(err, "failed to read random bytes")
+	}
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:]), nil
+}
+
+func keccak256(input ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, b := range input {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
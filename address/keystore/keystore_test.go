@@ -0,0 +1,171 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+// test vectors of https://github.com/ethereum/wiki/wiki/Web3-Secret-Storage-Definition
+const (
+	vectorPassword   = "testpassword"
+	vectorPrivateKey = "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+	vectorAddress    = "0x008AeEda4D805471dF9b2A5B0f38A0C3bCBA786b"
+	vectorPBKDF2     = `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},` +
+		`"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46","kdf":"pbkdf2",` +
+		`"kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256",` +
+		`"salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},` +
+		`"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},` +
+		`"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+	vectorScrypt = `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"83dbcc02d8ccb40e466191a123791e0e"},` +
+		`"ciphertext":"d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c","kdf":"scrypt",` +
+		`"kdfparams":{"dklen":32,"n":262144,"r":1,"p":8,` +
+		`"salt":"ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},` +
+		`"mac":"2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"},` +
+		`"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+)
+
+func TestDecryptVectors(t *testing.T) {
+	r := require.New(t)
+
+	expected, err := address.FromHexChecked(vectorAddress)
+	r.NoError(err)
+	for _, v := range []string{vectorPBKDF2, vectorScrypt} {
+		key, err := Decrypt([]byte(v), vectorPassword)
+		r.NoError(err)
+		r.Equal(vectorPrivateKey, hex.EncodeToString(key.PrivateKey))
+		r.Equal("3198bc9c-6672-5ab3-d995-4942343ae5b6", key.ID)
+		r.True(address.Equal(expected, key.Address))
+		r.Equal(vectorAddress, key.Hex())
+		r.Equal(expected.String(), key.String())
+
+		_, err = Decrypt([]byte(v), "wrongpassword")
+		r.Equal(ErrDecrypt, err)
+	}
+
+	// the address field in any form must match the key
+	for _, addr := range []string{
+		"008aeeda4d805471df9b2a5b0f38a0c3bcba786b",
+		vectorAddress,
+		expected.String(),
+	} {
+		key, err := Decrypt([]byte(`{"address":"`+addr+`",`+vectorPBKDF2[1:]), vectorPassword)
+		r.NoError(err)
+		r.True(address.Equal(expected, key.Address))
+	}
+	other, err := address.FromString(address.StakingProtocolAddr)
+	r.NoError(err)
+	_, err = Decrypt([]byte(`{"address":"`+strings.TrimPrefix(other.Hex(), "0x")+`",`+vectorPBKDF2[1:]), vectorPassword)
+	var addrErr *AddressError
+	r.True(errors.As(err, &addrErr))
+	r.True(address.Equal(other, addrErr.Got))
+	r.True(address.Equal(expected, addrErr.Want))
+	r.True(errors.Is(err, ErrInvalidKeystore))
+	r.Contains(err.Error(), vectorAddress)
+	r.Contains(err.Error(), expected.String())
+	_, err = Decrypt([]byte(`{"address":"`+address.RewardingPoolAddr+`",`+vectorPBKDF2[1:]), vectorPassword)
+	r.True(errors.Is(err, ErrInvalidKeystore))
+}
+
+func TestEncrypt(t *testing.T) {
+	r := require.New(t)
+
+	sk, err := hex.DecodeString(vectorPrivateKey)
+	r.NoError(err)
+	key, err := NewKey(sk)
+	r.NoError(err)
+	r.Equal(vectorAddress, key.Hex())
+	r.Len(key.ID, 36)
+	r.Equal(byte('4'), key.ID[14])
+
+	for _, opts := range []Options{
+		LightOptions,
+		{KDF: KDFPBKDF2, Iterations: 1024},
+	} {
+		b, err := Encrypt(key, "secret", opts)
+		r.NoError(err)
+		var f map[string]interface{}
+		r.NoError(json.Unmarshal(b, &f))
+		r.Equal("008aeeda4d805471df9b2a5b0f38a0c3bcba786b", f["address"])
+		r.Equal(float64(Version), f["version"])
+		r.Equal(opts.KDF, f["crypto"].(map[string]interface{})["kdf"])
+
+		decrypted, err := Decrypt(b, "secret")
+		r.NoError(err)
+		r.Equal(key, decrypted)
+		_, err = Decrypt(b, "")
+		r.Equal(ErrDecrypt, err)
+	}
+
+	// the address is derived from the private key, rather than taken from the key
+	b, err := Encrypt(&Key{PrivateKey: sk}, "secret", LightOptions)
+	r.NoError(err)
+	decrypted, err := Decrypt(b, "secret")
+	r.NoError(err)
+	r.Equal(vectorAddress, decrypted.Hex())
+	r.True(address.Equal(key.Address, decrypted.Address))
+
+	for _, opts := range []Options{
+		{KDF: "argon2"},
+		{KDF: KDFScrypt, ScryptN: 1000, ScryptR: 8, ScryptP: 1},
+		{KDF: KDFScrypt, ScryptN: 1 << 20, ScryptR: 16, ScryptP: 1},
+		{KDF: KDFScrypt, ScryptN: 1 << 18, ScryptR: 8, ScryptP: 32},
+		{KDF: KDFPBKDF2},
+		{KDF: KDFPBKDF2, Iterations: 1 << 23},
+	} {
+		_, err = Encrypt(key, "secret", opts)
+		r.True(errors.Is(err, ErrInvalidKeystore))
+	}
+	for _, v := range []string{
+		"",
+		"00",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141",
+	} {
+		b, err := hex.DecodeString(v)
+		r.NoError(err)
+		_, err = NewKey(b)
+		r.True(errors.Is(err, ErrInvalidKey))
+	}
+}
+
+func TestDecryptMalformed(t *testing.T) {
+	r := require.New(t)
+
+	for _, v := range []string{
+		`[]`,
+		strings.Replace(vectorPBKDF2, `"version":3`, `"version":1`, 1),
+		strings.Replace(vectorPBKDF2, `aes-128-ctr`, `aes-128-cbc`, 1),
+		strings.Replace(vectorPBKDF2, `"kdf":"pbkdf2"`, `"kdf":"argon2"`, 1),
+		strings.Replace(vectorPBKDF2, `hmac-sha256`, `hmac-sha512`, 1),
+		strings.Replace(vectorPBKDF2, `"dklen":32`, `"dklen":16`, 1),
+		strings.Replace(vectorPBKDF2, `"c":262144`, `"c":0`, 1),
+		strings.Replace(vectorPBKDF2, `"iv":"6087`, `"iv":"zz87`, 1),
+		strings.Replace(vectorPBKDF2, `"mac":"517e`, `"mac":"517`, 1),
+		strings.Replace(vectorScrypt, `"n":262144`, `"n":262143`, 1),
+		// parameters beyond the bounds of memory and work
+		strings.Replace(vectorPBKDF2, `"c":262144`, `"c":1073741824`, 1),
+		strings.Replace(vectorScrypt, `"n":262144`, `"n":1`, 1),
+		strings.Replace(vectorScrypt, `"n":262144`, `"n":16777216`, 1),
+		strings.Replace(vectorScrypt, `"n":262144`, `"n":9223372036854775807`, 1),
+		strings.Replace(vectorScrypt, `"r":1,`, `"r":0,`, 1),
+		strings.Replace(vectorScrypt, `"r":1,`, `"r":1024,`, 1),
+		strings.Replace(vectorScrypt, `"p":8,`, `"p":-1,`, 1),
+		strings.Replace(vectorScrypt, `"p":8,`, `"p":1048576,`, 1),
+	} {
+		_, err := Decrypt([]byte(v), vectorPassword)
+		r.True(errors.Is(err, ErrInvalidKeystore), v)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MarshalText encodes the address into its bech32 string on the default network
+func (addr AddrV1) MarshalText() ([]byte, error) {
+	return []byte(addr.String()), nil
+}
+
+// UnmarshalText decodes an address from its bech32, legacy, or "0x"-prefixed hex form
+func (addr *AddrV1) UnmarshalText(text []byte) error {
+	a, err := parseText(string(text))
+	if err != nil {
+		return err
+	}
+	v1, ok := a.(*AddrV1)
+	if !ok {
+		return errors.Wrapf(ErrInvalidAddr, "%s is not a v1 address", text)
+	}
+	*addr = *v1
+	return nil
+}
+
+// MarshalJSON encodes the address into a JSON string of its bech32 form
+func (addr AddrV1) MarshalJSON() ([]byte, error) {
+	return json.Marshal(addr.String())
+}
+
+// UnmarshalJSON decodes an address from a JSON string of its bech32, legacy, or "0x"-prefixed hex form
+// JSON null is a no-op
+func (addr *AddrV1) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, addr.UnmarshalText)
+}
+
+// MarshalText encodes the special address into its special text
+func (addr AddrV1Special) MarshalText() ([]byte, error) {
+	return []byte(addr.String()), nil
+}
+
+// UnmarshalText decodes a special address from its special text
+func (addr *AddrV1Special) UnmarshalText(text []byte) error {
+	if !IsAddrV1Special(string(text)) {
+		return errors.Wrapf(ErrInvalidAddr, "%s is not a special address", text)
+	}
+	addr.addr = string(text)
+	return nil
+}
+
+// MarshalJSON encodes the special address into a JSON string of its special text
+func (addr AddrV1Special) MarshalJSON() ([]byte, error) {
+	return json.Marshal(addr.String())
+}
+
+// UnmarshalJSON decodes a special address from a JSON string of its special text
+// JSON null is a no-op
+func (addr *AddrV1Special) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, addr.UnmarshalText)
+}
+
+// JSON wraps an address of any version for struct fields, which encoding/json cannot unmarshal into the Address
+// interface type
+// It encodes the address into its bech32 or special string, and decodes the bech32, legacy, "0x"-prefixed hex, special
+// and V2 forms. A nil address is encoded into JSON null, and JSON null decodes into a nil address
+type JSON struct {
+	Address
+}
+
+// MarshalText encodes the address into its bech32 or special string, a nil address being an error
+func (j JSON) MarshalText() ([]byte, error) {
+	if j.Address == nil {
+		return nil, errors.Wrap(ErrInvalidAddr, "nil address")
+	}
+	return []byte(j.Address.String()), nil
+}
+
+// UnmarshalText decodes an address of any version
+func (j *JSON) UnmarshalText(text []byte) error {
+	a, err := parseText(string(text))
+	if err != nil {
+		return err
+	}
+	j.Address = a
+	return nil
+}
+
+// MarshalJSON encodes the address into a JSON string, or JSON null for a nil address
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if j.Address == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(j.Address.String())
+}
+
+// UnmarshalJSON decodes an address of any version from a JSON string, JSON null being a nil address
+func (j *JSON) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		j.Address = nil
+		return nil
+	}
+	return unmarshalJSONText(data, j.UnmarshalText)
+}
+
+// parseText decodes an address from its bech32, legacy, "0x"-prefixed hex, special or V2 form
+func parseText(s string) (Address, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return FromHexChecked(s)
+	}
+	addr, err := FromString(s)
+	if err == nil {
+		return addr, nil
+	}
+	// the legacy form must still carry the prefix of the default network
+	if strings.HasPrefix(s, DefaultNetwork().Prefix()+"1") {
+		if addr, legacyErr := FromStringLegacy(s); legacyErr == nil {
+			return addr, nil
+		}
+	}
+	return nil, err
+}
+
+func unmarshalJSONText(data []byte, unmarshalText func([]byte) error) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.Wrap(ErrInvalidAddr, err.Error())
+	}
+	return unmarshalText([]byte(s))
+}
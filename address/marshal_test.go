@@ -0,0 +1,162 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalAddrV1(t *testing.T) {
+	r := require.New(t)
+
+	const (
+		bech32Addr = "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5"
+		legacyAddr = "io1qp3mxh8gx8fkqmss9c6jsm979wuv6qpm0waw6vhxt0dwzze8xxzkqzy3lxu"
+	)
+	expected, err := FromString(bech32Addr)
+	r.NoError(err)
+
+	text, err := expected.(*AddrV1).MarshalText()
+	r.NoError(err)
+	r.Equal(bech32Addr, string(text))
+
+	for _, v := range []string{
+		bech32Addr,
+		legacyAddr,
+		expected.Hex(),
+		expected.(*AddrV1).ChecksumHex(),
+	} {
+		var addr AddrV1
+		r.NoError(addr.UnmarshalText([]byte(v)))
+		r.Equal(expected, &addr)
+	}
+	for _, v := range []string{
+		"",
+		"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h4",
+		"iota1qp3mxh8gx8fkqmss9c6jsm979wuv6q06dmq2",
+		"0x6cbe2bb8cd003b7bbaed32e65bdae10b273185",
+		RewardingPoolAddr,
+	} {
+		var addr AddrV1
+		r.True(errors.Is(addr.UnmarshalText([]byte(v)), ErrInvalidAddr))
+	}
+
+	// round-trip through struct fields
+	type account struct {
+		Owner    *AddrV1  `json:"owner"`
+		Operator *AddrV1  `json:"operator,omitempty"`
+		Pool     Address  `json:"pool"`
+		Holders  []AddrV1 `json:"holders"`
+	}
+	pool, err := FromString(StakingBucketPoolAddr)
+	r.NoError(err)
+	a := account{
+		Owner:   expected.(*AddrV1),
+		Pool:    pool,
+		Holders: []AddrV1{*expected.(*AddrV1)},
+	}
+	b, err := json.Marshal(&a)
+	r.NoError(err)
+	r.JSONEq(`{
+		"owner": "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5",
+		"pool": "io000000000000000000000000stakingprotocol",
+		"holders": ["io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5"]
+	}`, string(b))
+	decoded := account{Pool: &AddrV1Special{}}
+	r.NoError(json.Unmarshal(b, &decoded))
+	r.Equal(a, decoded)
+
+	r.NoError(json.Unmarshal([]byte(`{"owner": "`+expected.Hex()+`", "operator": null}`), &decoded))
+	r.Equal(expected, decoded.Owner)
+	r.Nil(decoded.Operator)
+	r.Error(json.Unmarshal([]byte(`{"owner": 1}`), &decoded))
+	r.Error(json.Unmarshal([]byte(`{"pool": "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5"}`), &decoded))
+}
+
+func TestMarshalValue(t *testing.T) {
+	r := require.New(t)
+
+	addr, err := FromString("io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5")
+	r.NoError(err)
+	special, err := FromString(RewardingPoolAddr)
+	r.NoError(err)
+
+	// value-typed fields marshal as strings too
+	type values struct {
+		Owner AddrV1        `json:"owner"`
+		Pool  AddrV1Special `json:"pool"`
+	}
+	v := values{Owner: *addr.(*AddrV1), Pool: *special.(*AddrV1Special)}
+	b, err := json.Marshal(v)
+	r.NoError(err)
+	r.JSONEq(`{"owner": "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5", "pool": "`+RewardingPoolAddr+`"}`, string(b))
+	var decoded values
+	r.NoError(json.Unmarshal(b, &decoded))
+	r.Equal(v, decoded)
+	text, err := v.Owner.MarshalText()
+	r.NoError(err)
+	r.Equal(addr.String(), string(text))
+}
+
+func TestMarshalJSONWrapper(t *testing.T) {
+	r := require.New(t)
+
+	v1, err := FromString("io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5")
+	r.NoError(err)
+	special, err := FromString(RewardingPoolAddr)
+	r.NoError(err)
+	v2, err := ToV2(v1, ContractType, MainnetChainID)
+	r.NoError(err)
+
+	// fields of the Address interface type round-trip through the wrapper
+	type config struct {
+		Owner    JSON  `json:"owner"`
+		Pool     JSON  `json:"pool"`
+		Contract JSON  `json:"contract"`
+		Operator JSON  `json:"operator"`
+		Backup   *JSON `json:"backup,omitempty"`
+	}
+	c := config{
+		Owner:    JSON{v1},
+		Pool:     JSON{special},
+		Contract: JSON{v2},
+		Backup:   &JSON{v1},
+	}
+	b, err := json.Marshal(&c)
+	r.NoError(err)
+	r.JSONEq(`{
+		"owner": "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5",
+		"pool": "`+RewardingPoolAddr+`",
+		"contract": "`+v2.String()+`",
+		"operator": null,
+		"backup": "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5"
+	}`, string(b))
+	var decoded config
+	r.NoError(json.Unmarshal(b, &decoded))
+	r.Equal(c, decoded)
+	r.Equal(Version2, decoded.Contract.Version())
+	r.Nil(decoded.Operator.Address)
+
+	// any input form decodes
+	for _, s := range []string{v1.Hex(), v1.(*AddrV1).ChecksumHex(), "io1qp3mxh8gx8fkqmss9c6jsm979wuv6qpm0waw6vhxt0dwzze8xxzkqzy3lxu"} {
+		var j JSON
+		r.NoError(json.Unmarshal([]byte(`"`+s+`"`), &j))
+		r.True(Equal(v1, j.Address))
+		r.NoError(j.UnmarshalText([]byte(s)))
+		r.True(Equal(v1, j.Address))
+	}
+	var j JSON
+	r.True(errors.Is(json.Unmarshal([]byte(`"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h4"`), &j), ErrInvalidAddr))
+	r.Nil(j.Address)
+	r.Error(json.Unmarshal([]byte(`1`), &j))
+	_, err = JSON{}.MarshalText()
+	r.True(errors.Is(err, ErrInvalidAddr))
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidNetwork indicates the invalid network error
+var ErrInvalidNetwork = errors.New("invalid network")
+
+// Network identifies an IoTeX network by the human readable prefix (HRP) of its addresses
+type Network struct {
+	name   string
+	prefix string
+}
+
+var (
+	// Mainnet is the IoTeX mainnet, whose addresses start with "io"
+	Mainnet = Network{name: "mainnet", prefix: MainnetPrefix}
+	// Testnet is the IoTeX testnet, whose addresses start with "it"
+	Testnet = Network{name: "testnet", prefix: TestnetPrefix}
+)
+
+var (
+	networksMtx sync.RWMutex
+	networks    = map[string]Network{
+		MainnetPrefix: Mainnet,
+		TestnetPrefix: Testnet,
+	}
+)
+
+// RegisterNetwork registers a custom network with the given name and address prefix
+// Registering the same name and prefix again is a no-op, but a prefix cannot be re-used by another name
+func RegisterNetwork(name, prefix string) (Network, error) {
+	if name == "" {
+		return Network{}, errors.Wrap(ErrInvalidNetwork, "empty network name")
+	}
+	if err := validatePrefix(prefix); err != nil {
+		return Network{}, err
+	}
+	networksMtx.Lock()
+	defer networksMtx.Unlock()
+	if n, ok := networks[prefix]; ok {
+		if n.name != name {
+			return Network{}, errors.Wrapf(ErrInvalidNetwork, "prefix %s is already used by network %s", prefix, n.name)
+		}
+		return n, nil
+	}
+	n := Network{name: name, prefix: prefix}
+	networks[prefix] = n
+	return n, nil
+}
+
+// NetworkFromPrefix returns the registered network that uses the prefix
+func NetworkFromPrefix(prefix string) (Network, bool) {
+	networksMtx.RLock()
+	defer networksMtx.RUnlock()
+	n, ok := networks[prefix]
+	return n, ok
+}
+
+// DefaultNetwork returns the network used by the package-level functions
+// It is the testnet if IOTEX_NETWORK_TYPE is set to "testnet", otherwise the mainnet
+func DefaultNetwork() Network {
+	if isTestNet {
+		return Testnet
+	}
+	return Mainnet
+}
+
+// Name returns the name of the network
+func (n Network) Name() string { return n.name }
+
+// Prefix returns the human readable prefix of the network's addresses
+func (n Network) Prefix() string { return n.prefix }
+
+// String returns the name of the network
+func (n Network) String() string { return n.name }
+
+// IsValid returns true if the network has a prefix, i.e., it is not the zero value
+func (n Network) IsValid() bool { return n.prefix != "" }
+
+// orDefault returns the network, or the default network if it is the zero value, so that no address is encoded
+// without a prefix
+func (n Network) orDefault() Network {
+	if !n.IsValid() {
+		return DefaultNetwork()
+	}
+	return n
+}
+
+// validatePrefix checks the prefix is a lowercase bech32 HRP
+func validatePrefix(prefix string) error {
+	// leave room for the separator, the 32-char payload and the 6-char checksum within 90 chars
+	if len(prefix) == 0 || len(prefix) > 51 {
+		return errors.Wrapf(ErrInvalidNetwork, "prefix length = %d, expecting 1 to 51", len(prefix))
+	}
+	for i := 0; i < len(prefix); i++ {
+		if prefix[i] < 33 || prefix[i] > 126 {
+			return errors.Wrapf(ErrInvalidNetwork, "invalid character in prefix: '%c'", prefix[i])
+		}
+	}
+	if prefix != strings.ToLower(prefix) {
+		return errors.Wrapf(ErrInvalidNetwork, "prefix %s is not lowercase", prefix)
+	}
+	if strings.IndexByte(prefix, '1') >= 0 {
+		return errors.Wrapf(ErrInvalidNetwork, "prefix %s contains the separator 1", prefix)
+	}
+	return nil
+}
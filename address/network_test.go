@@ -0,0 +1,121 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetwork(t *testing.T) {
+	r := require.New(t)
+
+	n, ok := NetworkFromPrefix(MainnetPrefix)
+	r.True(ok)
+	r.Equal(Mainnet, n)
+	n, ok = NetworkFromPrefix(TestnetPrefix)
+	r.True(ok)
+	r.Equal(Testnet, n)
+	_, ok = NetworkFromPrefix("xx")
+	r.False(ok)
+
+	// register custom networks
+	sub, err := RegisterNetwork("subchain", "sub")
+	r.NoError(err)
+	r.Equal("subchain", sub.Name())
+	r.Equal("sub", sub.Prefix())
+	again, err := RegisterNetwork("subchain", "sub")
+	r.NoError(err)
+	r.Equal(sub, again)
+	n, ok = NetworkFromPrefix("sub")
+	r.True(ok)
+	r.Equal(sub, n)
+	for _, v := range []struct {
+		name, prefix string
+	}{
+		{"other", "sub"},
+		{"mainnet2", MainnetPrefix},
+		{"", "abc"},
+		{"empty", ""},
+		{"upper", "Abc"},
+		{"separator", "a1c"},
+		{"space", "a c"},
+		{"long", strings.Repeat("a", 52)},
+	} {
+		_, err = RegisterNetwork(v.name, v.prefix)
+		r.True(errors.Is(err, ErrInvalidNetwork))
+	}
+}
+
+func TestStringOn(t *testing.T) {
+	r := require.New(t)
+
+	const (
+		mainnetAddr = "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5"
+		testnetAddr = "it1djlzhwxdqqahhwhdxtn9hkhppvnnrptqg05fuh"
+	)
+	addr, err := FromStringOn(Mainnet, mainnetAddr)
+	r.NoError(err)
+	r.Equal(mainnetAddr, addr.(*AddrV1).StringOn(Mainnet))
+	r.Equal(testnetAddr, addr.(*AddrV1).StringOn(Testnet))
+	r.Equal(testnetAddr, StringOn(Testnet, addr))
+
+	taddr, err := FromStringOn(Testnet, testnetAddr)
+	r.NoError(err)
+	r.True(Equal(addr, taddr))
+	_, err = FromStringOn(Testnet, mainnetAddr)
+	r.True(errors.Is(err, ErrInvalidAddr))
+	_, err = FromStringOn(Network{}, mainnetAddr)
+	r.Equal(ErrInvalidNetwork, err)
+
+	// the zero network encodes on the default network, rather than without a prefix
+	v2, err := ToV2(addr, AccountType, 0)
+	r.NoError(err)
+	for _, a := range []Address{addr, v2} {
+		r.Equal(a.String(), StringOn(Network{}, a))
+		r.Equal(a.String(), NewCodec(Network{}).String(a))
+	}
+	r.Equal(mainnetAddr, addr.(*AddrV1).StringOn(Network{}))
+	r.Equal(mainnetAddr, addr.(*AddrV1).StringOn(Mainnet))
+
+	// both networks can be used by one process
+	mainnet, testnet := NewCodec(Mainnet), NewCodec(Testnet)
+	r.Equal(Testnet, testnet.Network())
+	a1, err := mainnet.FromString(mainnetAddr)
+	r.NoError(err)
+	a2, err := testnet.FromString(testnetAddr)
+	r.NoError(err)
+	r.Equal(a1, a2)
+	r.Equal(mainnetAddr, mainnet.String(a2))
+	r.Equal(testnetAddr, testnet.String(a1))
+	a2, err = testnet.FromStringLegacy(testnetAddr)
+	r.NoError(err)
+	r.Equal(a1, a2)
+
+	// special address is not bound to a network
+	special, err := testnet.FromString(RewardingPoolAddr)
+	r.NoError(err)
+	r.Equal(RewardingPoolAddr, testnet.String(special))
+
+	// custom network
+	sub, err := RegisterNetwork("subchain", "sub")
+	r.NoError(err)
+	s := NewCodec(sub).String(addr)
+	r.True(strings.HasPrefix(s, "sub1"))
+	r.Equal(len(sub.Prefix())+v1PayloadStringLength, len(s))
+	a3, err := NewCodec(sub).FromString(s)
+	r.NoError(err)
+	r.Equal(addr, a3)
+	_, err = NewCodec(Mainnet).FromString(s)
+	r.True(errors.Is(err, ErrInvalidAddr))
+	a3, err = NewCodec(sub).FromStringLegacy(s)
+	r.NoError(err)
+	r.Equal(addr, a3)
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Format is the form in which an address string is encoded
+type Format int
+
+const (
+	// FormatBech32 is the bech32 encoding of the 20-byte hash, e.g., io1...
+	FormatBech32 Format = iota + 1
+	// FormatLegacy is the bech32 encoding of a payload longer than 20 bytes, of which the last 20 bytes are the hash
+	FormatLegacy
+	// FormatHex is the "0x"-prefixed hex encoding of the 20-byte hash
+	FormatHex
+	// FormatSpecial is the special text of a special address
+	FormatSpecial
+	// FormatV2 is the bech32m encoding of a V2 address
+	FormatV2
+)
+
+// String returns the name of the format
+func (f Format) String() string {
+	switch f {
+	case FormatBech32:
+		return "bech32"
+	case FormatLegacy:
+		return "legacy"
+	case FormatHex:
+		return "hex"
+	case FormatSpecial:
+		return "special"
+	case FormatV2:
+		return "v2"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(f))
+	}
+}
+
+// ParseOptions is the policy of Parse, the zero value of which only accepts a lowercase bech32 address of the
+// default network
+type ParseOptions struct {
+	// Network is the network of bech32 addresses, the zero value means the default network
+	Network Network
+	// ChainID rejects a V2 address bound to another chain with ChainError, if it is not 0
+	ChainID uint32
+	// AllowUppercase accepts an all-uppercase bech32 address
+	AllowUppercase bool
+	// AllowLegacy accepts a legacy address longer than a bech32 address
+	AllowLegacy bool
+	// AllowHex accepts a "0x"-prefixed hex address, whose EIP-55 checksum is verified if it is mixed-case
+	AllowHex bool
+	// AllowSpecial accepts a special address
+	AllowSpecial bool
+	// AllowV2 accepts a V2 address
+	AllowV2 bool
+	// AllowAnyNetwork accepts a bech32 address of any registered network, not only Network
+	AllowAnyNetwork bool
+}
+
+var (
+	// StrictParseOptions only accepts a lowercase bech32 address of the default network, e.g., for exchanges
+	StrictParseOptions = ParseOptions{}
+	// LenientParseOptions accepts an address in any form and of any network, e.g., for explorers
+	LenientParseOptions = ParseOptions{
+		AllowUppercase:  true,
+		AllowLegacy:     true,
+		AllowHex:        true,
+		AllowSpecial:    true,
+		AllowV2:         true,
+		AllowAnyNetwork: true,
+	}
+)
+
+// rules of ParseOptions reported by PolicyError
+const (
+	RuleUppercase = "uppercase"
+	RuleLegacy    = "legacy"
+	RuleHex       = "hex"
+	RuleSpecial   = "special"
+	RuleV2        = "v2"
+	RuleNetwork   = "network"
+)
+
+// PolicyError reports an address string that is valid, but not allowed by the rule of ParseOptions
+type PolicyError struct {
+	Rule string
+}
+
+// Error returns the error message
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("%s address not allowed: %v", e.Rule, ErrInvalidAddr)
+}
+
+// Unwrap returns ErrInvalidAddr
+func (e *PolicyError) Unwrap() error { return ErrInvalidAddr }
+
+// Cause returns ErrInvalidAddr, for errors.Cause of github.com/pkg/errors
+func (e *PolicyError) Cause() error { return ErrInvalidAddr }
+
+// Parse decodes an address string according to the options, and returns the address with the detected format
+func Parse(s string, opts ParseOptions) (Address, Format, error) {
+	net := opts.Network
+	if !net.IsValid() {
+		net = DefaultNetwork()
+	}
+	if IsAddrV1Special(s) {
+		if !opts.AllowSpecial {
+			return nil, 0, &PolicyError{Rule: RuleSpecial}
+		}
+		return newAddrV1Special(s), FormatSpecial, nil
+	}
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		addr, err := FromHexChecked(s)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !opts.AllowHex {
+			return nil, 0, &PolicyError{Rule: RuleHex}
+		}
+		return addr, FormatHex, nil
+	}
+
+	// the prefix determines the network of the bech32 address
+	one := strings.LastIndexByte(s, '1')
+	if one < 1 {
+		return nil, 0, errors.Wrap(ErrInvalidAddr, "missing separator 1")
+	}
+	otherNetwork := false
+	if hrp := strings.ToLower(s[:one]); hrp != net.Prefix() {
+		other, ok := NetworkFromPrefix(hrp)
+		if !ok {
+			return nil, 0, &HRPError{Got: hrp, Want: net.Prefix()}
+		}
+		net, otherNetwork = other, true
+	}
+	format := FormatBech32
+	addr, err := FromStringOn(net, s)
+	if _, ok := addr.(*AddrV2); ok {
+		format = FormatV2
+	}
+	if err != nil {
+		var lengthErr *LengthError
+		if !errors.As(err, &lengthErr) || lengthErr.Got < lengthErr.Want {
+			return nil, 0, err
+		}
+		// longer than a bech32 address
+		if addr, err = FromStringLegacyOn(net, s); err != nil {
+			return nil, 0, err
+		}
+		format = FormatLegacy
+	}
+
+	if opts.ChainID != 0 {
+		if err := CheckChain(opts.ChainID, addr, true); err != nil {
+			return nil, 0, err
+		}
+	}
+	// the address is valid, check it against the policy
+	switch {
+	case otherNetwork && !opts.AllowAnyNetwork:
+		return nil, 0, &PolicyError{Rule: RuleNetwork}
+	case hasUpper(s) && !opts.AllowUppercase:
+		return nil, 0, &PolicyError{Rule: RuleUppercase}
+	case format == FormatLegacy && !opts.AllowLegacy:
+		return nil, 0, &PolicyError{Rule: RuleLegacy}
+	case format == FormatV2 && !opts.AllowV2:
+		return nil, 0, &PolicyError{Rule: RuleV2}
+	}
+	return addr, format, nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	r := require.New(t)
+
+	const (
+		bech32Addr  = "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5"
+		testnetAddr = "it1djlzhwxdqqahhwhdxtn9hkhppvnnrptqg05fuh"
+		legacyAddr  = "io1qp3mxh8gx8fkqmss9c6jsm979wuv6qpm0waw6vhxt0dwzze8xxzkqzy3lxu"
+	)
+	expected, err := FromString(bech32Addr)
+	r.NoError(err)
+	hexAddr := expected.(*AddrV1).ChecksumHex()
+
+	for _, v := range []struct {
+		s      string
+		format Format
+		rule   string
+	}{
+		{bech32Addr, FormatBech32, ""},
+		{strings.ToUpper(bech32Addr), FormatBech32, RuleUppercase},
+		{testnetAddr, FormatBech32, RuleNetwork},
+		{legacyAddr, FormatLegacy, RuleLegacy},
+		{hexAddr, FormatHex, RuleHex},
+		{expected.Hex(), FormatHex, RuleHex},
+		{RewardingPoolAddr, FormatSpecial, RuleSpecial},
+		{"io1qgqxe03thrxsqwmmhtkn9ejmmtsskfe3s4sqmv75q7", FormatV2, RuleV2},
+	} {
+		addr, format, err := Parse(v.s, LenientParseOptions)
+		r.NoError(err)
+		r.Equal(v.format, format)
+		if format == FormatSpecial {
+			r.Equal(v.s, addr.String())
+		} else {
+			r.True(Equal(expected, addr))
+		}
+
+		addr, format, err = Parse(v.s, StrictParseOptions)
+		if v.rule == "" {
+			r.NoError(err)
+			r.Equal(v.format, format)
+			r.True(Equal(expected, addr))
+			continue
+		}
+		var policyErr *PolicyError
+		r.True(errors.As(err, &policyErr))
+		r.Equal(v.rule, policyErr.Rule)
+		r.True(errors.Is(err, ErrInvalidAddr))
+		r.Nil(addr)
+	}
+
+	// the network of the options
+	addr, format, err := Parse(testnetAddr, ParseOptions{Network: Testnet})
+	r.NoError(err)
+	r.Equal(FormatBech32, format)
+	r.True(Equal(expected, addr))
+	_, _, err = Parse(bech32Addr, ParseOptions{Network: Testnet})
+	r.True(errors.Is(err, ErrInvalidAddr))
+
+	// invalid addresses are rejected regardless of the policy
+	for _, s := range []string{
+		"",
+		"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h4",
+		"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2H5",
+		"xx1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5",
+		"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2",
+		"0x6cbe2bb8cd003b7bbaed32e65bdae10b273185",
+		strings.Replace(hexAddr, "c", "C", 1),
+	} {
+		_, _, err := Parse(s, LenientParseOptions)
+		r.True(errors.Is(err, ErrInvalidAddr), s)
+		var policyErr *PolicyError
+		r.False(errors.As(err, &policyErr))
+	}
+
+	r.Equal("bech32", FormatBech32.String())
+	r.Equal("special", FormatSpecial.String())
+	r.Equal("unknown(0)", Format(0).String())
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v3"
+	"github.com/pkg/errors"
+)
+
+const (
+	// CompressedPublicKeyLength is the byte length of a compressed secp256k1 public key
+	CompressedPublicKeyLength = 33
+	// UncompressedPublicKeyLength is the byte length of an uncompressed secp256k1 public key
+	UncompressedPublicKeyLength = 65
+	// RawPublicKeyLength is the byte length of an uncompressed secp256k1 public key without the 0x04 prefix
+	RawPublicKeyLength = 64
+)
+
+// ErrInvalidPublicKey indicates the invalid public key error
+var ErrInvalidPublicKey = errors.New("invalid public key")
+
+// FromPublicKey derives the address from a secp256k1 public key, which is either compressed (33 bytes),
+// uncompressed (65 bytes), or uncompressed without the 0x04 prefix (64 bytes)
+func FromPublicKey(pk []byte) (Address, error) { return _v1.FromPublicKey(pk) }
+
+// FromECDSAPublicKey derives the address from a secp256k1 public key
+func FromECDSAPublicKey(pk *ecdsa.PublicKey) (Address, error) { return _v1.FromECDSAPublicKey(pk) }
+
+// FromPublicKey derives the address from a secp256k1 public key
+// The address is the last 20 bytes of the keccak256 hash of the uncompressed public key excluding its first byte
+func (v *v1) FromPublicKey(pk []byte) (Address, error) {
+	if len(pk) == RawPublicKeyLength {
+		pk = append([]byte{0x04}, pk...)
+	}
+	if len(pk) != CompressedPublicKeyLength && len(pk) != UncompressedPublicKeyLength {
+		return nil, errors.Wrapf(ErrInvalidPublicKey, "public key length = %d, expecting 33, 64 or 65", len(pk))
+	}
+	key, err := secp256k1.ParsePubKey(pk)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidPublicKey, err.Error())
+	}
+	h := hash160b(key.SerializeUncompressed()[1:])
+	return v.FromBytes(h[:])
+}
+
+// FromECDSAPublicKey derives the address from a secp256k1 public key
+func (v *v1) FromECDSAPublicKey(pk *ecdsa.PublicKey) (Address, error) {
+	if pk == nil || pk.X == nil || pk.Y == nil {
+		return nil, errors.Wrap(ErrInvalidPublicKey, "nil public key")
+	}
+	if pk.X.Sign() < 0 || pk.Y.Sign() < 0 || pk.X.BitLen() > 256 || pk.Y.BitLen() > 256 {
+		return nil, errors.Wrap(ErrInvalidPublicKey, "coordinate out of range")
+	}
+	raw := make([]byte, RawPublicKeyLength)
+	x, y := pk.X.Bytes(), pk.Y.Bytes()
+	copy(raw[32-len(x):32], x)
+	copy(raw[64-len(y):], y)
+	return v.FromPublicKey(raw)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v3"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromPublicKey(t *testing.T) {
+	r := require.New(t)
+
+	// public key of private key 1 is the generator point
+	const (
+		compressed   = "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+		uncompressed = "0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+			"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"
+	)
+	for _, v := range []string{compressed, uncompressed, uncompressed[2:]} {
+		pk, err := hex.DecodeString(v)
+		r.NoError(err)
+		addr, err := FromPublicKey(pk)
+		r.NoError(err)
+		r.Equal("0x7e5f4552091a69125d5dfcb7b8c2659029395bdf", addr.Hex())
+	}
+
+	// known answers of the corresponding Ethereum addresses
+	for _, v := range []struct {
+		sk, hex string
+	}{
+		{"0000000000000000000000000000000000000000000000000000000000000002", "0x2b5ad5c4795c026514f8317c7a215e218dccd6cf"},
+		{"0000000000000000000000000000000000000000000000000000000000000003", "0x6813eb9362372eef6200f3b1dbc3f819671cba69"},
+		{"4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318", "0x2c7536e3605d9c16a7a3d7b1898e529396a65c23"},
+	} {
+		b, err := hex.DecodeString(v.sk)
+		r.NoError(err)
+		pk := secp256k1.PrivKeyFromBytes(b).PubKey()
+		addr1, err := FromPublicKey(pk.SerializeCompressed())
+		r.NoError(err)
+		r.Equal(v.hex, addr1.Hex())
+		addr2, err := FromPublicKey(pk.SerializeUncompressed())
+		r.NoError(err)
+		r.True(Equal(addr1, addr2))
+		addr3, err := FromECDSAPublicKey(pk.ToECDSA())
+		r.NoError(err)
+		r.True(Equal(addr1, addr3))
+		r.True(strings.HasPrefix(addr1.String(), "io1"))
+	}
+
+	// invalid keys
+	for _, v := range []string{
+		"",
+		"0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f817",   // too short
+		"0579be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", // wrong format byte
+		"0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+			"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b9", // not on curve
+	} {
+		pk, err := hex.DecodeString(v)
+		r.NoError(err)
+		_, err = FromPublicKey(pk)
+		r.True(errors.Is(err, ErrInvalidPublicKey))
+	}
+	_, err := FromECDSAPublicKey(nil)
+	r.True(errors.Is(err, ErrInvalidPublicKey))
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// names of the built-in protocol addresses
+const (
+	StakingProtocolName   = "staking"
+	RewardingProtocolName = "rewarding"
+	StakingBucketPoolName = "stakingBucketPool"
+	RewardingPoolName     = "rewardingPool"
+)
+
+// ErrProtocolRegistered indicates the protocol name or address is already registered
+var ErrProtocolRegistered = errors.New("protocol already registered")
+
+// registry keeps the named protocol addresses, which are either derived from the hash of the name, or special text
+type registry struct {
+	mtx    sync.RWMutex
+	byName map[string]Address
+	byText map[string]string
+	byHash map[Hash160]string
+}
+
+// _registry is a singleton and holds the registered protocol addresses
+var _registry = newRegistry()
+
+func newRegistry() *registry {
+	r := &registry{
+		byName: map[string]Address{},
+		byText: map[string]string{},
+		byHash: map[Hash160]string{},
+	}
+	for _, name := range []string{StakingProtocolName, RewardingProtocolName} {
+		if _, err := r.registerProtocol(name); err != nil {
+			panic(err)
+		}
+	}
+	for name, text := range map[string]string{
+		StakingBucketPoolName: StakingBucketPoolAddr,
+		RewardingPoolName:     RewardingPoolAddr,
+	} {
+		if _, err := r.registerSpecial(name, text); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// ProtocolHash returns the 20-byte hash of a protocol address derived from the name, i.e., the last 20 bytes of the
+// keccak256 hash of the name, whether or not the protocol is registered
+func ProtocolHash(name string) Hash160 { return hash160b([]byte(name)) }
+
+// RegisterProtocol registers a protocol address, whose 20-byte hash is derived from the name
+// Registering the same name again returns the registered address
+func RegisterProtocol(name string) (Address, error) {
+	addr, err := _registry.registerProtocol(name)
+	return clone(addr), err
+}
+
+// RegisterSpecialAddr registers a special address, which is the special text of the same length as a v1 address
+// It must not be a valid bech32 string, so that it cannot be confused with the encoding of a 20-byte hash
+func RegisterSpecialAddr(name, text string) (Address, error) {
+	addr, err := _registry.registerSpecial(name, text)
+	return clone(addr), err
+}
+
+// ProtocolByName returns a copy of the protocol address registered with the name
+func ProtocolByName(name string) (Address, bool) {
+	_registry.mtx.RLock()
+	defer _registry.mtx.RUnlock()
+	addr, ok := _registry.byName[name]
+	return clone(addr), ok
+}
+
+// ProtocolByHash returns the name and a copy of the protocol address of the 20-byte hash
+func ProtocolByHash(h Hash160) (string, Address, bool) {
+	_registry.mtx.RLock()
+	defer _registry.mtx.RUnlock()
+	name, ok := _registry.byHash[h]
+	if !ok {
+		return "", nil, false
+	}
+	return name, clone(_registry.byName[name]), true
+}
+
+// ProtocolByString returns the name and a copy of the protocol address of the special text, or of the encoded address
+// string on the default network
+func ProtocolByString(s string) (string, Address, bool) {
+	_registry.mtx.RLock()
+	name, ok := _registry.byText[s]
+	_registry.mtx.RUnlock()
+	if ok {
+		addr, _ := ProtocolByName(name)
+		return name, addr, true
+	}
+	addr, err := FromString(s)
+	if err != nil {
+		return "", nil, false
+	}
+	v1, ok := addr.(*AddrV1)
+	if !ok {
+		return "", nil, false
+	}
+	return ProtocolByHash(v1.payload)
+}
+
+// Protocols returns the names of the registered protocol addresses in sorted order
+func Protocols() []string {
+	_registry.mtx.RLock()
+	defer _registry.mtx.RUnlock()
+	names := make([]string, 0, len(_registry.byName))
+	for name := range _registry.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// clone returns a copy of the registered address, so that a caller modifying it through Bytes or UnmarshalText cannot
+// corrupt the registry
+func clone(addr Address) Address {
+	switch a := addr.(type) {
+	case *AddrV1:
+		c := *a
+		return &c
+	case *AddrV1Special:
+		c := *a
+		return &c
+	}
+	return addr
+}
+
+// isSpecial returns true if the text is a registered special address
+func (r *registry) isSpecial(s string) bool {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	_, ok := r.byText[s]
+	return ok
+}
+
+func (r *registry) registerProtocol(name string) (Address, error) {
+	if name == "" {
+		return nil, errors.New("empty protocol name")
+	}
+	h := ProtocolHash(name)
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if addr, ok := r.byName[name]; ok {
+		if a, ok := addr.(*AddrV1); ok && a.payload == h {
+			return addr, nil
+		}
+		return nil, errors.Wrapf(ErrProtocolRegistered, "name %s", name)
+	}
+	if other, ok := r.byHash[h]; ok {
+		return nil, errors.Wrapf(ErrProtocolRegistered, "hash of %s is used by %s", name, other)
+	}
+	addr, err := _v1.FromBytes(h[:])
+	if err != nil {
+		return nil, err
+	}
+	r.byName[name] = addr
+	r.byHash[h] = name
+	return addr, nil
+}
+
+func (r *registry) registerSpecial(name, text string) (Address, error) {
+	if name == "" {
+		return nil, errors.New("empty protocol name")
+	}
+	if len(text) != V1AddressStringLength {
+		return nil, errors.Wrapf(ErrInvalidAddr, "special address length = %d, expecting %d", len(text), V1AddressStringLength)
+	}
+	for _, net := range []Network{Mainnet, Testnet} {
+		if _, err := _v1.decodeBech32(net.Prefix(), text); err == nil {
+			return nil, errors.Wrapf(ErrInvalidAddr, "special address %s is a valid bech32 address", text)
+		}
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if addr, ok := r.byName[name]; ok {
+		if addr.String() == text {
+			return addr, nil
+		}
+		return nil, errors.Wrapf(ErrProtocolRegistered, "name %s", name)
+	}
+	if other, ok := r.byText[text]; ok {
+		return nil, errors.Wrapf(ErrProtocolRegistered, "special address %s is used by %s", text, other)
+	}
+	addr := newAddrV1Special(text)
+	r.byName[name] = addr
+	r.byText[text] = name
+	return addr, nil
+}
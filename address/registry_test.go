@@ -0,0 +1,138 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	r := require.New(t)
+
+	// built-in protocol addresses
+	for _, v := range []struct {
+		name, addr string
+	}{
+		{StakingProtocolName, StakingProtocolAddr},
+		{RewardingProtocolName, RewardingProtocol},
+		{StakingBucketPoolName, StakingBucketPoolAddr},
+		{RewardingPoolName, RewardingPoolAddr},
+	} {
+		addr, ok := ProtocolByName(v.name)
+		r.True(ok)
+		r.Equal(v.addr, addr.String())
+		name, addr2, ok := ProtocolByString(v.addr)
+		r.True(ok)
+		r.Equal(v.name, name)
+		r.Equal(addr, addr2)
+	}
+	name, addr, ok := ProtocolByHash(StakingProtocolAddrHash)
+	r.True(ok)
+	r.Equal(StakingProtocolName, name)
+	r.Equal(StakingProtocolAddr, addr.String())
+	_, _, ok = ProtocolByHash(Hash160{})
+	r.False(ok)
+	_, _, ok = ProtocolByString(ZeroAddress)
+	r.False(ok)
+	_, ok = ProtocolByName("unknown")
+	r.False(ok)
+
+	// subchain protocols
+	addr, err := RegisterProtocol("subchain.bridge")
+	r.NoError(err)
+	h := hash160b([]byte("subchain.bridge"))
+	r.Equal(h[:], addr.Bytes())
+	addr2, err := RegisterProtocol("subchain.bridge")
+	r.NoError(err)
+	r.Equal(addr, addr2)
+	name, addr2, ok = ProtocolByHash(h)
+	r.True(ok)
+	r.Equal("subchain.bridge", name)
+	r.Equal(addr, addr2)
+	r.Contains(Protocols(), "subchain.bridge")
+
+	const special = "io000000000000000000000subchainbridgepool"
+	r.False(IsAddrV1Special(special))
+	_, err = FromString(special)
+	r.Error(err)
+	addr, err = RegisterSpecialAddr("subchainBridgePool", special)
+	r.NoError(err)
+	r.True(IsAddrV1Special(special))
+	addr2, err = FromString(special)
+	r.NoError(err)
+	r.True(Equal(addr, addr2))
+	_, err = TryBytes(addr2)
+	r.True(errors.Is(err, ErrNoBytes))
+	name, _, ok = ProtocolByString(special)
+	r.True(ok)
+	r.Equal("subchainBridgePool", name)
+
+	// conflicts
+	for _, err := range []error{
+		func() error { _, err := RegisterSpecialAddr(StakingProtocolName, special); return err }(),
+		func() error { _, err := RegisterSpecialAddr("other", special); return err }(),
+		func() error { _, err := RegisterSpecialAddr("subchainBridgePool", RewardingPoolAddr); return err }(),
+		func() error { _, err := RegisterProtocol(StakingBucketPoolName); return err }(),
+	} {
+		r.True(errors.Is(err, ErrProtocolRegistered))
+	}
+	for _, err := range []error{
+		func() error { _, err := RegisterSpecialAddr("short", "io00protocol"); return err }(),
+		func() error { _, err := RegisterSpecialAddr("bech32", ZeroAddress); return err }(),
+	} {
+		r.True(errors.Is(err, ErrInvalidAddr))
+	}
+	_, err = RegisterProtocol("")
+	r.Error(err)
+	_, err = RegisterSpecialAddr("", special)
+	r.Error(err)
+}
+
+func TestRegistryCopies(t *testing.T) {
+	r := require.New(t)
+
+	// modifying a returned address does not corrupt the registry
+	addr, ok := ProtocolByName(StakingProtocolName)
+	r.True(ok)
+	addr.Bytes()[0] ^= 0xff
+	r.NoError(addr.(*AddrV1).UnmarshalText([]byte(ZeroAddress)))
+	_, addr, ok = ProtocolByHash(StakingProtocolAddrHash)
+	r.True(ok)
+	addr.Bytes()[0] ^= 0xff
+	_, addr, ok = ProtocolByString(StakingProtocolAddr)
+	r.True(ok)
+	addr.Bytes()[0] ^= 0xff
+	addr, err := RegisterProtocol(StakingProtocolName)
+	r.NoError(err)
+	addr.Bytes()[0] ^= 0xff
+	addr, ok = ProtocolByName(StakingProtocolName)
+	r.True(ok)
+	r.Equal(StakingProtocolAddr, addr.String())
+	r.Equal(StakingProtocolAddrHash[:], addr.Bytes())
+
+	special, ok := ProtocolByName(RewardingPoolName)
+	r.True(ok)
+	r.NoError(special.(*AddrV1Special).UnmarshalText([]byte(StakingBucketPoolAddr)))
+	special, ok = ProtocolByName(RewardingPoolName)
+	r.True(ok)
+	r.Equal(RewardingPoolAddr, special.String())
+}
+
+func TestProtocolHash(t *testing.T) {
+	r := require.New(t)
+
+	r.Equal(StakingProtocolAddrHash, ProtocolHash(StakingProtocolName))
+	r.Equal(RewardingProtocolAddrHash, ProtocolHash(RewardingProtocolName))
+	// unregistered names derive addresses too
+	h := ProtocolHash("unregistered")
+	_, _, ok := ProtocolByHash(h)
+	r.False(ok)
+	r.Equal(hash160b([]byte("unregistered")), h)
+}
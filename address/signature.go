@@ -0,0 +1,83 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"strconv"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v3/ecdsa"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SignatureLength is the byte length of a recoverable secp256k1 signature, i.e., R || S || V
+	SignatureLength = 65
+	// personalMessagePrefix is prepended to a message signed by personal_sign
+	personalMessagePrefix = "\x19Ethereum Signed Message:\n"
+)
+
+var (
+	// ErrInvalidSignature indicates a malformed signature, or one no public key can be recovered from
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrWrongSigner indicates a valid signature of another address
+	ErrWrongSigner = errors.New("signature of another address")
+)
+
+// RecoverAddress recovers the address of the key that signed the 32-byte hash
+// The signature is R || S || V, in which V is the recovery ID 0 or 1, or 27 or 28 as returned by Ethereum wallets. As
+// the ecrecover of Ethereum, a signature of high S value is accepted
+func RecoverAddress(hash, sig []byte) (Address, error) {
+	if len(hash) != HashLength {
+		return nil, errors.Wrapf(ErrInvalidSignature, "hash length = %d, expecting %d", len(hash), HashLength)
+	}
+	if len(sig) != SignatureLength {
+		return nil, errors.Wrapf(ErrInvalidSignature, "signature length = %d, expecting %d", len(sig), SignatureLength)
+	}
+	v := sig[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return nil, errors.Wrapf(ErrInvalidSignature, "recovery ID = %d, expecting 0, 1, 27 or 28", sig[64])
+	}
+	// the compact signature of decred is V || R || S, in which V is offset by 27
+	compact := make([]byte, SignatureLength)
+	compact[0] = 27 + v
+	copy(compact[1:], sig[:64])
+	pk, _, err := ecdsa.RecoverCompact(compact, hash)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidSignature, err.Error())
+	}
+	return _v1.FromPublicKey(pk.SerializeUncompressed())
+}
+
+// PersonalMessageHash returns the hash signed by personal_sign and eth_sign for the message, i.e., the keccak256 hash
+// of "\x19Ethereum Signed Message:\n", the decimal length of the message, and the message
+func PersonalMessageHash(msg []byte) []byte {
+	return keccak256([]byte(personalMessagePrefix+strconv.Itoa(len(msg))), msg)
+}
+
+// RecoverPersonalAddress recovers the address that signed the message with personal_sign
+func RecoverPersonalAddress(msg, sig []byte) (Address, error) {
+	return RecoverAddress(PersonalMessageHash(msg), sig)
+}
+
+// VerifyAddressSignature returns nil if the address signed the message with personal_sign
+// The address may be of any form sharing the 20-byte hash of the signer, e.g., a V2 account address of any chain
+func VerifyAddressSignature(addr Address, msg, sig []byte) error {
+	if addr == nil {
+		return errors.Wrap(ErrInvalidAddr, "nil address")
+	}
+	signer, err := RecoverPersonalAddress(msg, sig)
+	if err != nil {
+		return err
+	}
+	if !Equal(addr, signer) {
+		return errors.Wrapf(ErrWrongSigner, "signed by %s, expecting %s", signer, addr)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v3"
+	"github.com/decred/dcrd/dcrec/secp256k1/v3/ecdsa"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// signPersonal signs the message with personal_sign, and returns R || S || V with V being 27 or 28
+func signPersonal(sk *secp256k1.PrivateKey, msg []byte) []byte {
+	compact := ecdsa.SignCompact(sk, PersonalMessageHash(msg), false)
+	return append(compact[1:], compact[0])
+}
+
+func TestRecoverAddress(t *testing.T) {
+	r := require.New(t)
+
+	// known answer of web3.eth.accounts.sign("Some data", key)
+	const (
+		signer  = "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"
+		msgHash = "1da44b586eb0729ff70a73c326926f6ed5a25f5b056e7f47fbc6e58d86871655"
+		sigHex  = "b91467e570a6466aa9e9876cbcd013baba02900b8979d43fe208a4a4f339f5fd" +
+			"6007e74cd82e037b800186422fc2da167c747ef045e5d18a5f5d4300f8e1a029" + "1c"
+	)
+	msg := []byte("Some data")
+	r.Equal(msgHash, hex.EncodeToString(PersonalMessageHash(msg)))
+	sig, err := hex.DecodeString(sigHex)
+	r.NoError(err)
+	expected, err := FromHexChecked(signer)
+	r.NoError(err)
+	addr, err := RecoverPersonalAddress(msg, sig)
+	r.NoError(err)
+	r.True(Equal(expected, addr))
+	r.Equal(Version1, addr.Version())
+	hash, err := hex.DecodeString(msgHash)
+	r.NoError(err)
+	addr, err = RecoverAddress(hash, sig)
+	r.NoError(err)
+	r.True(Equal(expected, addr))
+
+	// recovery ID 0 or 1 is the same as 27 or 28
+	raw := append([]byte{}, sig...)
+	raw[64] -= 27
+	addr, err = RecoverAddress(hash, raw)
+	r.NoError(err)
+	r.True(Equal(expected, addr))
+
+	// the other recovery ID recovers another key
+	raw[64] ^= 1
+	addr, err = RecoverAddress(hash, raw)
+	r.NoError(err)
+	r.False(Equal(expected, addr))
+
+	for _, v := range []struct {
+		hash, sig []byte
+	}{
+		{hash[:31], sig},
+		{hash, sig[:64]},
+		{hash, append(sig, 0)},
+		{hash, append(append([]byte{}, sig[:64]...), 2)},
+		{hash, append(append([]byte{}, sig[:64]...), 29)},
+		{hash, append(make([]byte, 64), 27)},
+	} {
+		_, err = RecoverAddress(v.hash, v.sig)
+		r.True(errors.Is(err, ErrInvalidSignature))
+	}
+}
+
+func TestVerifyAddressSignature(t *testing.T) {
+	r := require.New(t)
+
+	b, err := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	r.NoError(err)
+	sk := secp256k1.PrivKeyFromBytes(b)
+	addr, err := FromPublicKey(sk.PubKey().SerializeCompressed())
+	r.NoError(err)
+	msg := []byte("sign in to iotex as " + addr.String())
+	sig := signPersonal(sk, msg)
+
+	r.NoError(VerifyAddressSignature(addr, msg, sig))
+	// any form of the address
+	for _, s := range []string{addr.String(), addr.Hex(), StringOn(Testnet, addr)} {
+		a, _, err := Parse(s, LenientParseOptions)
+		r.NoError(err)
+		r.NoError(VerifyAddressSignature(a, msg, sig))
+	}
+	v2, err := ToV2(addr, AccountType, MainnetChainID)
+	r.NoError(err)
+	r.NoError(VerifyAddressSignature(v2, msg, sig))
+
+	// wrong signer
+	other, err := FromString(StakingProtocolAddr)
+	r.NoError(err)
+	err = VerifyAddressSignature(other, msg, sig)
+	r.True(errors.Is(err, ErrWrongSigner))
+	err = VerifyAddressSignature(addr, []byte("another message"), sig)
+	r.True(errors.Is(err, ErrWrongSigner))
+	special, err := FromString(RewardingPoolAddr)
+	r.NoError(err)
+	r.True(errors.Is(VerifyAddressSignature(special, msg, sig), ErrWrongSigner))
+
+	// malformed
+	r.True(errors.Is(VerifyAddressSignature(addr, msg, sig[:64]), ErrInvalidSignature))
+	r.True(errors.Is(VerifyAddressSignature(nil, msg, sig), ErrInvalidAddr))
+}
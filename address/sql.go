@@ -0,0 +1,144 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+)
+
+// SQLFormat is the representation in which an SQLValue stores an address into a database
+type SQLFormat int
+
+const (
+	// SQLBytes stores the 20-byte hash, e.g., into a BYTEA or BINARY(20) column
+	SQLBytes SQLFormat = iota
+	// SQLBech32 stores the bech32 string on the network of the SQLValue, e.g., into a TEXT column
+	SQLBech32
+	// SQLHex stores the "0x"-prefixed lowercase hex string, e.g., into a TEXT column
+	SQLHex
+)
+
+// SQLValue stores an address into a database in the format, encoding the bech32 string on the network, so that
+// databases of different formats and networks can be used in one process
+// Special addresses are always stored as text since they have no 20-byte hash, and a nil address is stored as NULL
+type SQLValue struct {
+	Address Address
+	Format  SQLFormat
+	// Network is the network of the bech32 string, the default network if zero
+	Network Network
+}
+
+// SQLValue returns the SQLValue storing the address in the format, encoding the bech32 string on the codec's network
+func (c *Codec) SQLValue(addr Address, f SQLFormat) SQLValue {
+	return SQLValue{Address: addr, Format: f, Network: c.net}
+}
+
+// Value implements driver.Valuer
+func (v SQLValue) Value() (driver.Value, error) {
+	if v.Address == nil {
+		return nil, nil
+	}
+	b, err := TryBytes(v.Address)
+	if err != nil {
+		return v.Address.String(), nil
+	}
+	switch v.Format {
+	case SQLBytes:
+		return append([]byte{}, b...), nil
+	case SQLBech32:
+		return StringOn(v.Network, v.Address), nil
+	case SQLHex:
+		return "0x" + hex.EncodeToString(b), nil
+	default:
+		return nil, errors.Errorf("unknown SQL format %d", v.Format)
+	}
+}
+
+// Value implements driver.Valuer, storing the 20-byte hash
+// Use SQLValue to store the address as text
+func (addr AddrV1) Value() (driver.Value, error) {
+	return append([]byte{}, addr.payload[:]...), nil
+}
+
+// Scan implements sql.Scanner, accepting the 20-byte hash, or the bech32, legacy, or hex string of an address
+func (addr *AddrV1) Scan(src interface{}) error {
+	a, err := sqlScan(src)
+	if err != nil {
+		return err
+	}
+	v1, ok := a.(*AddrV1)
+	if !ok {
+		return errors.Wrapf(ErrInvalidAddr, "%s is not a v1 address", a.String())
+	}
+	*addr = *v1
+	return nil
+}
+
+// Value implements driver.Valuer, storing the special text
+func (addr AddrV1Special) Value() (driver.Value, error) {
+	return addr.String(), nil
+}
+
+// Scan implements sql.Scanner, accepting the special text
+func (addr *AddrV1Special) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return addr.UnmarshalText([]byte(v))
+	case []byte:
+		return addr.UnmarshalText(v)
+	default:
+		return errors.Wrapf(ErrInvalidAddr, "cannot scan %T into special address", src)
+	}
+}
+
+// Value implements driver.Valuer, storing the 20-byte hash
+func (h Hash160) Value() (driver.Value, error) {
+	return append([]byte{}, h[:]...), nil
+}
+
+// Scan implements sql.Scanner, accepting the 20-byte hash, or the bech32, legacy, or hex string of an address
+func (h *Hash160) Scan(src interface{}) error {
+	a, err := sqlScan(src)
+	if err != nil {
+		return err
+	}
+	v1, ok := a.(*AddrV1)
+	if !ok {
+		return errors.Wrapf(ErrInvalidAddr, "%s has no 20-byte hash", a.String())
+	}
+	*h = v1.payload
+	return nil
+}
+
+func sqlScan(src interface{}) (Address, error) {
+	switch v := src.(type) {
+	case []byte:
+		if len(v) == _v1.AddressLength {
+			return _v1.FromBytes(v)
+		}
+		return sqlScanText(string(v))
+	case string:
+		return sqlScanText(v)
+	case nil:
+		return nil, errors.Wrap(ErrInvalidAddr, "cannot scan NULL into address")
+	default:
+		return nil, errors.Wrapf(ErrInvalidAddr, "cannot scan %T into address", src)
+	}
+}
+
+func sqlScanText(s string) (Address, error) {
+	// hex string without the "0x" prefix
+	if len(s) == 2*_v1.AddressLength {
+		if _, err := hex.DecodeString(s); err == nil {
+			s = "0x" + s
+		}
+	}
+	return parseText(s)
+}
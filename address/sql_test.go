@@ -0,0 +1,132 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package address
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ sql.Scanner   = (*AddrV1)(nil)
+	_ driver.Valuer = AddrV1{}
+	_ sql.Scanner   = (*AddrV1Special)(nil)
+	_ driver.Valuer = AddrV1Special{}
+	_ sql.Scanner   = (*Hash160)(nil)
+	_ driver.Valuer = Hash160{}
+	_ driver.Valuer = SQLValue{}
+)
+
+func TestSQL(t *testing.T) {
+	r := require.New(t)
+
+	expected, err := FromString(StakingProtocolAddr)
+	r.NoError(err)
+	addr := expected.(*AddrV1)
+
+	// addresses and hashes are stored as the 20-byte hash, also as values and nil pointers of query arguments
+	for _, arg := range []interface{}{addr, *addr, StakingProtocolAddrHash, &StakingProtocolAddrHash} {
+		v, err := driver.DefaultParameterConverter.ConvertValue(arg)
+		r.NoError(err)
+		r.Equal(StakingProtocolAddrHash[:], v)
+		var scanned AddrV1
+		r.NoError(scanned.Scan(v))
+		r.Equal(addr, &scanned)
+		var h Hash160
+		r.NoError(h.Scan(v))
+		r.Equal(StakingProtocolAddrHash, h)
+	}
+	for _, arg := range []interface{}{(*AddrV1)(nil), (*AddrV1Special)(nil), (*Hash160)(nil), SQLValue{}} {
+		v, err := driver.DefaultParameterConverter.ConvertValue(arg)
+		r.NoError(err)
+		r.Nil(v)
+	}
+
+	// every format round-trips, on the network of the value
+	v2, err := ToV2(addr, ProtocolType, 0)
+	r.NoError(err)
+	for _, v := range []struct {
+		value    SQLValue
+		expected driver.Value
+		// whether the stored value scans back into the v1 address on the default network
+		scan bool
+	}{
+		{SQLValue{Address: addr}, StakingProtocolAddrHash[:], true},
+		{SQLValue{Address: addr, Format: SQLBech32}, StakingProtocolAddr, true},
+		{NewCodec(Mainnet).SQLValue(addr, SQLBech32), StakingProtocolAddr, true},
+		{NewCodec(Testnet).SQLValue(addr, SQLBech32), addr.StringOn(Testnet), false},
+		{SQLValue{Address: addr, Format: SQLHex, Network: Testnet}, addr.Hex(), true},
+		{SQLValue{Address: v2, Format: SQLBytes}, StakingProtocolAddrHash[:], true},
+		{SQLValue{Address: v2, Format: SQLBech32}, v2.String(), false},
+		{SQLValue{Address: v2, Format: SQLHex}, addr.Hex(), true},
+	} {
+		value, err := driver.DefaultParameterConverter.ConvertValue(v.value)
+		r.NoError(err)
+		r.Equal(v.expected, value)
+		if v.scan {
+			var scanned AddrV1
+			r.NoError(scanned.Scan(value))
+			r.Equal(addr, &scanned)
+		}
+	}
+	_, err = SQLValue{Address: addr, Format: SQLFormat(10)}.Value()
+	r.Error(err)
+
+	// text columns may hold any form
+	for _, v := range []interface{}{
+		StakingProtocolAddr,
+		[]byte(StakingProtocolAddr),
+		addr.Hex(),
+		addr.ChecksumHex(),
+		addr.Hex()[2:],
+	} {
+		var scanned AddrV1
+		r.NoError(scanned.Scan(v))
+		r.Equal(addr, &scanned)
+		var h Hash160
+		r.NoError(h.Scan(v))
+		r.Equal(StakingProtocolAddrHash, h)
+	}
+	for _, v := range []interface{}{
+		nil,
+		int64(1),
+		[]byte{1, 2, 3},
+		"io1qnpz47hx5q6r3w876axtrn6yz95d70cjl35r54",
+		RewardingPoolAddr,
+	} {
+		var scanned AddrV1
+		r.True(errors.Is(scanned.Scan(v), ErrInvalidAddr))
+		var h Hash160
+		r.True(errors.Is(h.Scan(v), ErrInvalidAddr))
+	}
+
+	// special addresses round-trip through text
+	for _, s := range []string{RewardingPoolAddr, StakingBucketPoolAddr} {
+		special, err := FromString(s)
+		r.NoError(err)
+		v, err := special.(*AddrV1Special).Value()
+		r.NoError(err)
+		r.Equal(s, v)
+		for _, f := range []SQLFormat{SQLBytes, SQLBech32, SQLHex} {
+			value, err := SQLValue{Address: special, Format: f}.Value()
+			r.NoError(err)
+			r.Equal(s, value)
+		}
+		var scanned AddrV1Special
+		r.NoError(scanned.Scan(v))
+		r.Equal(special, &scanned)
+		r.NoError(scanned.Scan([]byte(s)))
+		r.Equal(special, &scanned)
+	}
+	var special AddrV1Special
+	r.True(errors.Is(special.Scan(StakingProtocolAddr), ErrInvalidAddr))
+	r.True(errors.Is(special.Scan(nil), ErrInvalidAddr))
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+// EIP681Scheme is the scheme of EIP-681 URI
+const EIP681Scheme = "ethereum"
+
+// parameters and function of EIP-681 URI
+const (
+	eip681Pay      = "pay-"
+	eip681Value    = "value"
+	eip681Gas      = "gas"
+	eip681Transfer = "transfer"
+	eip681Address  = "address"
+	eip681Uint256  = "uint256"
+)
+
+// FromEIP681 converts an EIP-681 URI of an ether or ERC-20 transfer, i.e.,
+//
+//	ethereum:[pay-]<recipient>[@<chain ID>]?value=<number>&gasLimit=<number>&gasPrice=<number>
+//	ethereum:[pay-]<token>[@<chain ID>]/transfer?address=<recipient>&uint256=<number>&gasLimit=<number>&gasPrice=<number>
+//
+// Addresses must be "0x"-prefixed hex, ENS names are not supported
+func FromEIP681(s string) (*URI, error) {
+	scheme, target, query, err := split(s)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(scheme, EIP681Scheme) {
+		return nil, &SchemeError{Scheme: scheme}
+	}
+	target = strings.TrimPrefix(target, eip681Pay)
+	function := ""
+	if slash := strings.IndexByte(target, '/'); slash >= 0 {
+		target, function = target[:slash], target[slash+1:]
+	}
+	if err := checkHex("target", target); err != nil {
+		return nil, err
+	}
+	contract, chainID, err := parseTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	params, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	u := URI{ChainID: chainID}
+	switch function {
+	case "":
+		u.Recipient = contract
+	case eip681Transfer:
+		u.Token = contract
+	default:
+		return nil, &ParamError{Param: "function", Value: function}
+	}
+	for _, k := range sortedKeys(params) {
+		v := params.Get(k)
+		switch {
+		case k == eip681Value && u.Token == nil:
+			u.Amount, err = parseNumber(k, v)
+		case k == eip681Uint256 && u.Token != nil:
+			u.Amount, err = parseNumber(k, v)
+		case k == eip681Address && u.Token != nil:
+			if err = checkHex(k, v); err == nil {
+				u.Recipient, err = parseAddress(k, v)
+			}
+		case k == ParamGasLimit || k == eip681Gas:
+			u.GasLimit, err = parseUint64(k, v)
+		case k == ParamGasPrice:
+			u.GasPrice, err = parseNumber(k, v)
+		default:
+			err = u.addParam(k, v)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if u.Recipient == nil {
+		return nil, &ParamError{Param: eip681Address}
+	}
+	return &u, nil
+}
+
+// EIP681 converts the URI into an EIP-681 URI, in which addresses are in EIP-55 checksummed hex form
+// The memo cannot be carried by EIP-681, and returns LossError
+func (u *URI) EIP681() (string, error) {
+	if u.Memo != "" {
+		return "", &LossError{Field: ParamMemo}
+	}
+	recipient, err := checksumHex("recipient", u.Recipient)
+	if err != nil {
+		return "", err
+	}
+	var (
+		b strings.Builder
+		q query
+	)
+	b.WriteString(EIP681Scheme)
+	b.WriteByte(':')
+	if u.Token == nil {
+		b.WriteString(recipient)
+		if u.Amount != nil {
+			q.add(eip681Value, u.Amount.String())
+		}
+	} else {
+		token, err := checksumHex(ParamToken, u.Token)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(token)
+		q.add(eip681Address, recipient)
+		if u.Amount != nil {
+			q.add(eip681Uint256, u.Amount.String())
+		}
+	}
+	if u.ChainID != 0 {
+		b.WriteByte('@')
+		b.WriteString(strconv.FormatUint(u.ChainID, 10))
+	}
+	if u.Token != nil {
+		b.WriteByte('/')
+		b.WriteString(eip681Transfer)
+	}
+	u.writeGas(&q)
+	q.addValues(u.Params)
+	b.WriteString(q.String())
+	return b.String(), nil
+}
+
+// checkHex returns AddressError if the address is not "0x"-prefixed
+func checkHex(param, s string) error {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return nil
+	}
+	return &AddressError{Param: param, Err: errors.Wrapf(address.ErrInvalidAddr, "%s is not a hex address", s)}
+}
+
+// checksumHex returns the EIP-55 checksummed hex form of the address
+func checksumHex(param string, addr address.Address) (string, error) {
+	if addr == nil {
+		return "", &AddressError{Param: param, Err: errors.Wrap(address.ErrInvalidAddr, "missing address")}
+	}
+	v1, err := address.ToV1(addr)
+	if err != nil {
+		return "", &AddressError{Param: param, Err: err}
+	}
+	return v1.ChecksumHex(), nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidURI indicates the invalid URI error, which every error of the package wraps
+var ErrInvalidURI = errors.New("invalid URI")
+
+type (
+	// SchemeError reports a URI of another scheme
+	SchemeError struct {
+		Scheme string
+	}
+
+	// AmountError reports a malformed number, such as a negative or fractional amount
+	AmountError struct {
+		Param string
+		Value string
+	}
+
+	// ParamError reports a malformed or duplicate parameter
+	ParamError struct {
+		Param string
+		Value string
+	}
+
+	// RequiredParamError reports an unknown parameter prefixed with "req-", which the URI requires to be understood
+	RequiredParamError struct {
+		Param string
+	}
+
+	// LossError reports a field that the target URI scheme cannot carry
+	LossError struct {
+		Field string
+	}
+)
+
+// Error returns the error message
+func (e *SchemeError) Error() string {
+	return fmt.Sprintf("unknown scheme %q: %v", e.Scheme, ErrInvalidURI)
+}
+
+// Unwrap returns ErrInvalidURI
+func (e *SchemeError) Unwrap() error { return ErrInvalidURI }
+
+// Error returns the error message
+func (e *AmountError) Error() string {
+	return fmt.Sprintf("malformed number %s=%q: %v", e.Param, e.Value, ErrInvalidURI)
+}
+
+// Unwrap returns ErrInvalidURI
+func (e *AmountError) Unwrap() error { return ErrInvalidURI }
+
+// Error returns the error message
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("malformed parameter %s=%q: %v", e.Param, e.Value, ErrInvalidURI)
+}
+
+// Unwrap returns ErrInvalidURI
+func (e *ParamError) Unwrap() error { return ErrInvalidURI }
+
+// Error returns the error message
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("unknown required parameter %s: %v", e.Param, ErrInvalidURI)
+}
+
+// Unwrap returns ErrInvalidURI
+func (e *RequiredParamError) Unwrap() error { return ErrInvalidURI }
+
+// Error returns the error message
+func (e *LossError) Error() string {
+	return fmt.Sprintf("%s cannot be converted: %v", e.Field, ErrInvalidURI)
+}
+
+// Unwrap returns ErrInvalidURI
+func (e *LossError) Unwrap() error { return ErrInvalidURI }
+
+// AddressError reports a malformed address in the parameter, and wraps the error of the address package
+type AddressError struct {
+	Param string
+	Err   error
+}
+
+// Error returns the error message
+func (e *AddressError) Error() string {
+	return fmt.Sprintf("malformed %s: %v", e.Param, e.Err)
+}
+
+// Unwrap returns the error of the address package
+func (e *AddressError) Unwrap() error { return e.Err }
+
+// Is returns true for ErrInvalidURI
+func (e *AddressError) Is(target error) bool { return target == ErrInvalidURI }
@@ -0,0 +1,318 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package uri parses and builds iotex: payment URIs of the form
+//
+//	iotex:<recipient>[@<chain ID>]?amount=<number>&token=<address>&memo=<text>&gasLimit=<number>&gasPrice=<number>
+//
+// in the style of BIP-21, and converts them from and to EIP-681 ethereum: URIs. Addresses are either bech32, e.g.,
+// io1..., or "0x"-prefixed hex. Numbers are in the smallest unit, i.e., Rau for IOTX or the base unit of a token, and
+// follow the syntax of EIP-681, so 1 IOTX can be written as 1e18.
+package uri
+
+import (
+	"math/big"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+// Scheme is the scheme of IoTeX payment URI
+const Scheme = "iotex"
+
+// parameters of IoTeX payment URI
+const (
+	ParamAmount   = "amount"
+	ParamToken    = "token"
+	ParamMemo     = "memo"
+	ParamGasLimit = "gasLimit"
+	ParamGasPrice = "gasPrice"
+	ParamChainID  = "chainID"
+)
+
+// requiredPrefix marks a parameter which must be understood, as in BIP-21
+const requiredPrefix = "req-"
+
+// URI is a payment request
+type URI struct {
+	// Recipient receives the payment
+	Recipient address.Address
+	// ChainID is the EVM chain ID, or 0 if absent
+	ChainID uint64
+	// Amount is the amount in the smallest unit, or nil if absent
+	Amount *big.Int
+	// Token is the token contract, or nil for IOTX
+	Token address.Address
+	// Memo is the message attached to the payment
+	Memo string
+	// GasLimit is the gas limit hint, or 0 if absent
+	GasLimit uint64
+	// GasPrice is the gas price hint in Rau, or nil if absent
+	GasPrice *big.Int
+	// Params holds the unknown optional parameters
+	Params url.Values
+}
+
+// Parse parses an iotex: URI
+func Parse(s string) (*URI, error) {
+	scheme, target, query, err := split(s)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(scheme, Scheme) {
+		return nil, &SchemeError{Scheme: scheme}
+	}
+	u := URI{}
+	if u.Recipient, u.ChainID, err = parseTarget(target); err != nil {
+		return nil, err
+	}
+	params, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range sortedKeys(params) {
+		v := params.Get(k)
+		switch k {
+		case ParamAmount:
+			u.Amount, err = parseNumber(k, v)
+		case ParamToken:
+			u.Token, err = parseAddress(k, v)
+		case ParamMemo:
+			u.Memo = v
+		case ParamGasLimit:
+			u.GasLimit, err = parseUint64(k, v)
+		case ParamGasPrice:
+			u.GasPrice, err = parseNumber(k, v)
+		default:
+			err = u.addParam(k, v)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &u, nil
+}
+
+// String builds the iotex: URI, in which addresses are in bech32 form
+func (u *URI) String() string {
+	var b strings.Builder
+	b.WriteString(Scheme)
+	b.WriteByte(':')
+	if u.Recipient != nil {
+		b.WriteString(u.Recipient.String())
+	}
+	if u.ChainID != 0 {
+		b.WriteByte('@')
+		b.WriteString(strconv.FormatUint(u.ChainID, 10))
+	}
+	var q query
+	if u.Amount != nil {
+		q.add(ParamAmount, u.Amount.String())
+	}
+	if u.Token != nil {
+		q.add(ParamToken, u.Token.String())
+	}
+	if u.Memo != "" {
+		q.add(ParamMemo, u.Memo)
+	}
+	u.writeGas(&q)
+	q.addValues(u.Params)
+	b.WriteString(q.String())
+	return b.String()
+}
+
+func (u *URI) writeGas(q *query) {
+	if u.GasLimit != 0 {
+		q.add(ParamGasLimit, strconv.FormatUint(u.GasLimit, 10))
+	}
+	if u.GasPrice != nil {
+		q.add(ParamGasPrice, u.GasPrice.String())
+	}
+}
+
+func (u *URI) addParam(k, v string) error {
+	if strings.HasPrefix(k, requiredPrefix) {
+		return &RequiredParamError{Param: k}
+	}
+	if u.Params == nil {
+		u.Params = url.Values{}
+	}
+	u.Params.Set(k, v)
+	return nil
+}
+
+// split splits the URI into scheme, target and query
+func split(s string) (scheme, target, query string, err error) {
+	colon := strings.IndexByte(s, ':')
+	if colon < 0 {
+		return "", "", "", &SchemeError{}
+	}
+	scheme, target = s[:colon], s[colon+1:]
+	if q := strings.IndexByte(target, '?'); q >= 0 {
+		target, query = target[:q], target[q+1:]
+	}
+	return scheme, target, query, nil
+}
+
+// parseTarget parses the recipient and the optional chain ID
+func parseTarget(target string) (address.Address, uint64, error) {
+	var chainID uint64
+	if at := strings.IndexByte(target, '@'); at >= 0 {
+		var err error
+		if chainID, err = strconv.ParseUint(target[at+1:], 10, 64); err != nil || chainID == 0 {
+			return nil, 0, &ParamError{Param: ParamChainID, Value: target[at+1:]}
+		}
+		target = target[:at]
+	}
+	recipient, err := parseAddress("recipient", target)
+	if err != nil {
+		return nil, 0, err
+	}
+	return recipient, chainID, nil
+}
+
+// parseQuery parses the query, in which each parameter appears only once
+func parseQuery(s string) (url.Values, error) {
+	values, err := url.ParseQuery(s)
+	if err != nil {
+		return nil, &ParamError{Param: "query", Value: s}
+	}
+	for _, k := range sortedKeys(values) {
+		if v := values[k]; len(v) != 1 {
+			return nil, &ParamError{Param: k, Value: strings.Join(v, ",")}
+		}
+	}
+	return values, nil
+}
+
+// parseAddress parses a "0x"-prefixed hex address, whose EIP-55 checksum is verified if it is mixed-case, or a bech32
+// address
+func parseAddress(param, s string) (address.Address, error) {
+	var (
+		addr address.Address
+		err  error
+	)
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		addr, err = address.FromHexChecked(s)
+	} else {
+		addr, err = address.FromString(s)
+	}
+	if err != nil {
+		return nil, &AddressError{Param: param, Err: err}
+	}
+	return addr, nil
+}
+
+// maxNumberBits is the bit length of the largest number, i.e., uint256
+const maxNumberBits = 256
+
+// parseNumber parses a non-negative integer of the EIP-681 number syntax, e.g., 1000, 1.5e18 or 2E3
+func parseNumber(param, s string) (*big.Int, error) {
+	mantissa, exp := s, ""
+	if e := strings.IndexAny(s, "eE"); e >= 0 {
+		mantissa, exp = s[:e], s[e+1:]
+		if exp == "" {
+			return nil, &AmountError{Param: param, Value: s}
+		}
+	}
+	whole, frac := mantissa, ""
+	if dot := strings.IndexByte(mantissa, '.'); dot >= 0 {
+		whole, frac = mantissa[:dot], mantissa[dot+1:]
+		if frac == "" {
+			return nil, &AmountError{Param: param, Value: s}
+		}
+	}
+	if whole == "" || !isDigits(whole) || !isDigits(frac) || !isDigits(exp) {
+		return nil, &AmountError{Param: param, Value: s}
+	}
+	shift := 0
+	if exp != "" {
+		var err error
+		// no uint256 has more than 78 digits
+		if shift, err = strconv.Atoi(exp); err != nil || shift > 78+len(frac) {
+			return nil, &AmountError{Param: param, Value: s}
+		}
+	}
+	// drop the trailing zeros of the fraction, the rest must be shifted into the integer
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > shift {
+		return nil, &AmountError{Param: param, Value: s}
+	}
+	n, _ := new(big.Int).SetString(whole+frac, 10)
+	if shift -= len(frac); shift > 0 {
+		n.Mul(n, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(shift)), nil))
+	}
+	if n.BitLen() > maxNumberBits {
+		return nil, &AmountError{Param: param, Value: s}
+	}
+	return n, nil
+}
+
+// parseUint64 parses a number of the EIP-681 number syntax into uint64
+func parseUint64(param, s string) (uint64, error) {
+	n, err := parseNumber(param, s)
+	if err != nil {
+		return 0, err
+	}
+	if !n.IsUint64() {
+		return 0, &AmountError{Param: param, Value: s}
+	}
+	return n.Uint64(), nil
+}
+
+// sortedKeys returns the keys of the values in order
+func sortedKeys(values url.Values) []string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// query builds the query of URI in the order of the parameters added
+type query struct {
+	b strings.Builder
+}
+
+func (q *query) add(k, v string) {
+	if q.b.Len() == 0 {
+		q.b.WriteByte('?')
+	} else {
+		q.b.WriteByte('&')
+	}
+	q.b.WriteString(escape(k))
+	q.b.WriteByte('=')
+	q.b.WriteString(escape(v))
+}
+
+// addValues adds the values in the order of the keys
+func (q *query) addValues(values url.Values) {
+	for _, k := range sortedKeys(values) {
+		for _, v := range values[k] {
+			q.add(k, v)
+		}
+	}
+}
+
+func (q *query) String() string { return q.b.String() }
+
+// escape escapes the string for the query, in which a space is %20 rather than +
+func escape(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package uri
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+const (
+	recipient    = "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5"
+	recipientHex = "0x6cbE2Bb8cd003b7bbAed32e65BDAE10B27318560"
+	token        = "io1qnpz47hx5q6r3w876axtrn6yz95d70cjl35r53"
+)
+
+func TestParse(t *testing.T) {
+	r := require.New(t)
+
+	u, err := Parse("iotex:" + recipient + "@4689?amount=1.5e18&token=" + token +
+		"&memo=coffee%20%26%20cake&gasLimit=2e5&gasPrice=1000000000000&label=shop")
+	r.NoError(err)
+	r.Equal(recipient, u.Recipient.String())
+	r.Equal(uint64(4689), u.ChainID)
+	r.Equal("1500000000000000000", u.Amount.String())
+	r.Equal(token, u.Token.String())
+	r.Equal("coffee & cake", u.Memo)
+	r.Equal(uint64(200000), u.GasLimit)
+	r.Equal("1000000000000", u.GasPrice.String())
+	r.Equal("shop", u.Params.Get("label"))
+	r.Equal("iotex:"+recipient+"@4689?amount=1500000000000000000&token="+token+
+		"&memo=coffee%20%26%20cake&gasLimit=200000&gasPrice=1000000000000&label=shop", u.String())
+
+	// the round trip keeps the canonical form
+	again, err := Parse(u.String())
+	r.NoError(err)
+	r.Equal(u.String(), again.String())
+
+	// hex recipient and no parameters
+	u, err = Parse("IOTEX:" + recipientHex)
+	r.NoError(err)
+	r.Equal(recipient, u.Recipient.String())
+	r.Nil(u.Amount)
+	r.Nil(u.Token)
+	r.Equal("iotex:"+recipient, u.String())
+}
+
+func TestParseErrors(t *testing.T) {
+	r := require.New(t)
+
+	var (
+		schemeErr   *SchemeError
+		amountErr   *AmountError
+		paramErr    *ParamError
+		requiredErr *RequiredParamError
+		addrErr     *AddressError
+	)
+	for _, v := range []struct {
+		s      string
+		target interface{}
+	}{
+		{recipient, &schemeErr},
+		{"bitcoin:" + recipient, &schemeErr},
+		{"iotex:" + recipient + "?amount=-1", &amountErr},
+		{"iotex:" + recipient + "?amount=0.5", &amountErr},
+		{"iotex:" + recipient + "?amount=1.", &amountErr},
+		{"iotex:" + recipient + "?amount=1,5", &amountErr},
+		{"iotex:" + recipient + "?amount=1e", &amountErr},
+		{"iotex:" + recipient + "?amount=1e78", &amountErr},
+		{"iotex:" + recipient + "?amount=", &amountErr},
+		{"iotex:" + recipient + "?gasLimit=1e20", &amountErr},
+		{"iotex:" + recipient + "?gasPrice=0x10", &amountErr},
+		{"iotex:" + recipient + "?amount=1&amount=2", &paramErr},
+		{"iotex:" + recipient + "?memo=%zz", &paramErr},
+		{"iotex:" + recipient + "@0", &paramErr},
+		{"iotex:" + recipient + "@mainnet", &paramErr},
+		{"iotex:" + recipient + "?req-expiry=1", &requiredErr},
+		{"iotex:", &addrErr},
+		{"iotex:io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h4", &addrErr},
+		{"iotex:0x6cbe2bb8cd003b7bbaed32e65bdae10b273185", &addrErr},
+		{"iotex:0x6cBE2Bb8cd003b7bbAed32e65BDAE10B27318560", &addrErr},
+		{"iotex:" + recipient + "?token=" + recipient[:10], &addrErr},
+	} {
+		_, err := Parse(v.s)
+		r.True(errors.Is(err, ErrInvalidURI), v.s)
+		r.True(errors.As(err, v.target), v.s)
+	}
+	_, err := Parse("iotex:io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h4")
+	r.True(errors.Is(err, address.ErrInvalidAddr))
+
+	// the largest number
+	max := new(big.Int).Lsh(big.NewInt(1), 256)
+	max.Sub(max, big.NewInt(1))
+	u, err := Parse("iotex:" + recipient + "?amount=" + max.String())
+	r.NoError(err)
+	r.Equal(max, u.Amount)
+	_, err = Parse("iotex:" + recipient + "?amount=" + new(big.Int).Add(max, big.NewInt(1)).String())
+	r.True(errors.As(err, &amountErr))
+	u, err = Parse("iotex:" + recipient + "?amount=1.50e1&gasLimit=21000")
+	r.NoError(err)
+	r.Equal(int64(15), u.Amount.Int64())
+}
+
+func TestEIP681(t *testing.T) {
+	r := require.New(t)
+
+	// ether transfer round-trips
+	const native = "ethereum:" + recipientHex + "@4689?value=1000000000000000000&gasLimit=21000&gasPrice=1000000000000"
+	u, err := FromEIP681(native)
+	r.NoError(err)
+	r.Equal(recipient, u.Recipient.String())
+	r.Nil(u.Token)
+	r.Equal(uint64(4689), u.ChainID)
+	r.Equal("1000000000000000000", u.Amount.String())
+	r.Equal(uint64(21000), u.GasLimit)
+	s, err := u.EIP681()
+	r.NoError(err)
+	r.Equal(native, s)
+	iotex, err := Parse(u.String())
+	r.NoError(err)
+	s, err = iotex.EIP681()
+	r.NoError(err)
+	r.Equal(native, s)
+
+	// ERC-20 transfer round-trips
+	tokenAddr, err := address.FromString(token)
+	r.NoError(err)
+	tokenHex := tokenAddr.(*address.AddrV1).ChecksumHex()
+	transfer := "ethereum:" + tokenHex + "@4689/transfer?address=" + recipientHex + "&uint256=5"
+	u, err = FromEIP681(transfer)
+	r.NoError(err)
+	r.Equal(recipient, u.Recipient.String())
+	r.Equal(token, u.Token.String())
+	r.Equal(int64(5), u.Amount.Int64())
+	s, err = u.EIP681()
+	r.NoError(err)
+	r.Equal(transfer, s)
+	r.Equal("iotex:"+recipient+"@4689?amount=5&token="+token, u.String())
+
+	// pay- prefix, gas alias and scientific notation
+	u, err = FromEIP681("ethereum:pay-" + recipientHex + "?value=2.014e18&gas=21000")
+	r.NoError(err)
+	r.Equal("2014000000000000000", u.Amount.String())
+	r.Equal(uint64(21000), u.GasLimit)
+
+	// memo cannot be carried
+	u.Memo = "hi"
+	_, err = u.EIP681()
+	var lossErr *LossError
+	r.True(errors.As(err, &lossErr))
+	r.Equal(ParamMemo, lossErr.Field)
+	special, err := address.FromString(address.RewardingPoolAddr)
+	r.NoError(err)
+	_, err = (&URI{Recipient: special}).EIP681()
+	r.True(errors.Is(err, address.ErrNoBytes))
+	r.True(errors.Is(err, ErrInvalidURI))
+
+	for _, s := range []string{
+		"iotex:" + recipientHex,
+		"ethereum:" + recipient,
+		"ethereum:vitalik.eth",
+		"ethereum:" + tokenHex + "/approve?address=" + recipientHex,
+		"ethereum:" + tokenHex + "/transfer?uint256=1",
+		"ethereum:" + tokenHex + "/transfer?address=" + recipient,
+		"ethereum:" + recipientHex + "?value=1.5",
+		"ethereum:" + recipientHex + "?req-foo=1",
+	} {
+		_, err := FromEIP681(s)
+		r.True(errors.Is(err, ErrInvalidURI), s)
+	}
+}
@@ -0,0 +1,339 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package vanity searches for secp256k1 keys whose address matches a pattern, e.g., io1team...
+//
+// Each worker starts from a random private key k, and tries k, k+1, k+2, ..., so that the public key of the next
+// attempt is a point addition rather than a scalar multiplication.
+package vanity
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"math"
+	"regexp"
+	"regexp/syntax"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v3"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-address/address/bech32"
+)
+
+const (
+	// dataLength is the length of the data part of an address string, i.e., the encoded 20-byte hash and checksum
+	dataLength = 32 + 6
+	// checkInterval is the number of attempts between checks of the context
+	checkInterval = 256
+	// defaultProgressInterval is the interval of progress reports
+	defaultProgressInterval = time.Second
+)
+
+// ErrInvalidPattern indicates the invalid pattern error
+var ErrInvalidPattern = errors.New("invalid pattern")
+
+// Options defines the pattern and the search
+type Options struct {
+	// Prefix is matched right after the separator, e.g., "team" matches io1team...
+	Prefix string
+	// Suffix is matched at the end of the address string, including the checksum
+	Suffix string
+	// Regexp is matched against the data part of the address string, i.e., without the network prefix and separator
+	Regexp string
+	// Network is the network to encode addresses on, the zero value means the default network
+	Network address.Network
+	// Workers is the number of goroutines to search on, 0 means runtime.NumCPU()
+	Workers int
+	// Progress is called with the number of attempts so far, every ProgressInterval
+	Progress func(attempts uint64)
+	// ProgressInterval is the interval of progress reports, 0 means 1 second
+	ProgressInterval time.Duration
+}
+
+// Result is the key found by the search
+type Result struct {
+	// PrivateKey is the 32-byte secp256k1 private key
+	PrivateKey []byte
+	// Address is the address of the private key
+	Address *address.AddrV1
+	// Attempts is the number of attempts of all workers
+	Attempts uint64
+}
+
+// Generator searches for keys matching the pattern
+type Generator struct {
+	opts   Options
+	prefix []byte
+	suffix []byte
+	regexp *regexp.Regexp
+}
+
+// NewGenerator validates the pattern and creates a generator
+// Prefix and suffix are case-insensitive, and must only contain characters of the bech32 charset, as must the
+// literals of the regular expression
+func NewGenerator(opts Options) (*Generator, error) {
+	opts.Prefix, opts.Suffix = strings.ToLower(opts.Prefix), strings.ToLower(opts.Suffix)
+	if opts.Prefix == "" && opts.Suffix == "" && opts.Regexp == "" {
+		return nil, errors.Wrap(ErrInvalidPattern, "empty pattern")
+	}
+	for _, p := range []struct {
+		name, s string
+		max     int
+	}{
+		// the encoded hash has 32 characters, the checksum has 6
+		{"prefix", opts.Prefix, 32},
+		{"suffix", opts.Suffix, dataLength},
+	} {
+		if len(p.s) > p.max {
+			return nil, errors.Wrapf(ErrInvalidPattern, "%s is longer than %d characters", p.name, p.max)
+		}
+		if err := checkCharset(p.name, p.s); err != nil {
+			return nil, err
+		}
+	}
+	if len(opts.Prefix)+len(opts.Suffix) > dataLength {
+		return nil, errors.Wrapf(ErrInvalidPattern, "prefix and suffix are longer than %d characters", dataLength)
+	}
+	if !opts.Network.IsValid() {
+		opts.Network = address.DefaultNetwork()
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	if opts.ProgressInterval <= 0 {
+		opts.ProgressInterval = defaultProgressInterval
+	}
+	g := Generator{opts: opts, prefix: []byte(opts.Prefix), suffix: []byte(opts.Suffix)}
+	if opts.Regexp != "" {
+		re, err := syntax.Parse(opts.Regexp, syntax.Perl)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidPattern, err.Error())
+		}
+		if err := checkRegexp(re); err != nil {
+			return nil, err
+		}
+		g.regexp = regexp.MustCompile(opts.Regexp)
+	}
+	return &g, nil
+}
+
+// checkCharset returns ErrInvalidPattern if s contains a character out of the bech32 charset
+func checkCharset(name, s string) error {
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(bech32.Charset, s[i]) < 0 {
+			return errors.Wrapf(ErrInvalidPattern, "character %q at position %d of %s is not in the bech32 charset",
+				s[i], i, name)
+		}
+	}
+	return nil
+}
+
+// checkRegexp returns ErrInvalidPattern if a literal is out of the bech32 charset, or a character class has no
+// character of it, since neither could ever match
+func checkRegexp(re *syntax.Regexp) error {
+	switch re.Op {
+	case syntax.OpLiteral:
+		s := string(re.Rune)
+		if re.Flags&syntax.FoldCase != 0 {
+			s = strings.ToLower(s)
+		}
+		if err := checkCharset("regexp", s); err != nil {
+			return err
+		}
+	case syntax.OpCharClass:
+		for _, c := range bech32.Charset {
+			if inClass(re.Rune, c) {
+				return nil
+			}
+		}
+		return errors.Wrapf(ErrInvalidPattern, "character class %s has no character of the bech32 charset", re)
+	}
+	for _, sub := range re.Sub {
+		if err := checkRegexp(sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inClass returns true if c is in the ranges of a character class
+func inClass(ranges []rune, c rune) bool {
+	for i := 0; i+1 < len(ranges); i += 2 {
+		if ranges[i] <= c && c <= ranges[i+1] {
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns true if the address string matches the pattern
+func (g *Generator) Match(s string) bool {
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 0 {
+		return false
+	}
+	return g.match([]byte(s[sep+1:]))
+}
+
+// match returns true if the data part of an address matches the pattern
+func (g *Generator) match(data []byte) bool {
+	if !bytes.HasPrefix(data, g.prefix) || !bytes.HasSuffix(data, g.suffix) {
+		return false
+	}
+	return g.regexp == nil || g.regexp.Match(data)
+}
+
+// ExpectedAttempts returns the expected number of attempts to find a match, which is 32^n for n characters of
+// prefix and suffix, and false if the pattern has a regular expression, whose odds are not estimated
+func (g *Generator) ExpectedAttempts() (float64, bool) {
+	if g.regexp != nil {
+		return 0, false
+	}
+	return math.Pow(float64(len(bech32.Charset)), float64(len(g.opts.Prefix)+len(g.opts.Suffix))), true
+}
+
+// Search runs the workers until a key is found, or the context is done
+func (g *Generator) Search(ctx context.Context) (*Result, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		attempts uint64
+		once     sync.Once
+		found    *Result
+		wg       sync.WaitGroup
+		errs     = make(chan error, g.opts.Workers)
+	)
+	for i := 0; i < g.opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := g.search(ctx, &attempts)
+			switch {
+			case err != nil:
+				errs <- err
+				cancel()
+			case res != nil:
+				once.Do(func() {
+					found = res
+					cancel()
+				})
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	if g.opts.Progress != nil {
+		ticker := time.NewTicker(g.opts.ProgressInterval)
+		defer ticker.Stop()
+	progress:
+		for {
+			select {
+			case <-ticker.C:
+				g.opts.Progress(atomic.LoadUint64(&attempts))
+			case <-done:
+				break progress
+			}
+		}
+	}
+	<-done
+
+	if found != nil {
+		found.Attempts = atomic.LoadUint64(&attempts)
+		return found, nil
+	}
+	select {
+	case err := <-errs:
+		return nil, err
+	default:
+		return nil, ctx.Err()
+	}
+}
+
+// search tries consecutive private keys from a random one, until a key is found or the context is done
+func (g *Generator) search(ctx context.Context, attempts *uint64) (*Result, error) {
+	var (
+		k, one secp256k1.ModNScalar
+		p, gen secp256k1.JacobianPoint
+		next   secp256k1.JacobianPoint
+		raw    [64]byte
+		hasher = sha3.NewLegacyKeccak256()
+		hash   []byte
+		// the address is encoded into buffers of the worker, rather than through address.AddrV1.StringOn, which
+		// shares a cache across the process
+		groupedBuf [32]byte
+		encodedBuf [90]byte
+		prefix     = g.opts.Network.Prefix()
+	)
+	if err := randomScalar(&k); err != nil {
+		return nil, err
+	}
+	one.SetInt(1)
+	secp256k1.ScalarBaseMultNonConst(&one, &gen)
+	gen.ToAffine()
+	secp256k1.ScalarBaseMultNonConst(&k, &p)
+	for i := 1; ; i++ {
+		p.ToAffine()
+		p.X.PutBytesUnchecked(raw[:32])
+		p.Y.PutBytesUnchecked(raw[32:])
+		hasher.Reset()
+		hasher.Write(raw[:])
+		hash = hasher.Sum(hash[:0])
+		grouped, err := bech32.AppendConvertBits(groupedBuf[:0], hash[12:], 8, 5, true)
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := bech32.AppendEncode(encodedBuf[:0], prefix, grouped)
+		if err != nil {
+			return nil, err
+		}
+		if g.match(encoded[len(prefix)+1:]) {
+			atomic.AddUint64(attempts, uint64(i%checkInterval))
+			addr, err := address.FromBytes(hash[12:])
+			if err != nil {
+				return nil, err
+			}
+			key := k.Bytes()
+			return &Result{PrivateKey: key[:], Address: addr.(*address.AddrV1)}, nil
+		}
+		if i%checkInterval == 0 {
+			atomic.AddUint64(attempts, checkInterval)
+			select {
+			case <-ctx.Done():
+				return nil, nil
+			default:
+			}
+		}
+		// the next key and its public key
+		k.Add(&one)
+		secp256k1.AddNonConst(&p, &gen, &next)
+		p.Set(&next)
+	}
+}
+
+// randomScalar sets k to a random valid private key
+func randomScalar(k *secp256k1.ModNScalar) error {
+	var b [32]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			return errors.Wrap(err, "failed to read random bytes")
+		}
+		if overflow := k.SetByteSlice(b[:]); !overflow && !k.IsZero() {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package vanity
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v3"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+func TestSearch(t *testing.T) {
+	r := require.New(t)
+
+	for _, opts := range []Options{
+		{Prefix: "q"},
+		{Prefix: "Z", Suffix: "l", Workers: 2},
+		{Regexp: "^[qp].*[0-9]$", Network: address.Testnet, Workers: 1},
+	} {
+		g, err := NewGenerator(opts)
+		r.NoError(err)
+		res, err := g.Search(context.Background())
+		r.NoError(err)
+		r.True(res.Attempts > 0)
+
+		// the private key derives the address
+		s := res.Address.StringOn(g.opts.Network)
+		r.True(g.Match(s), s)
+		r.True(strings.HasPrefix(s, g.opts.Network.Prefix()+"1"+strings.ToLower(opts.Prefix)))
+		r.True(strings.HasSuffix(s, opts.Suffix))
+		pk := secp256k1.PrivKeyFromBytes(res.PrivateKey).PubKey()
+		addr, err := address.FromPublicKey(pk.SerializeCompressed())
+		r.NoError(err)
+		r.True(address.Equal(res.Address, addr))
+	}
+}
+
+func TestCancel(t *testing.T) {
+	r := require.New(t)
+
+	var reports int32
+	g, err := NewGenerator(Options{
+		Prefix:           strings.Repeat("q", 20),
+		Workers:          2,
+		Progress:         func(uint64) { atomic.AddInt32(&reports, 1) },
+		ProgressInterval: 10 * time.Millisecond,
+	})
+	r.NoError(err)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	res, err := g.Search(ctx)
+	r.Nil(res)
+	r.Equal(context.DeadlineExceeded, err)
+	r.True(atomic.LoadInt32(&reports) > 0)
+}
+
+func TestPattern(t *testing.T) {
+	r := require.New(t)
+
+	for _, opts := range []Options{
+		{},
+		{Prefix: "team1"},
+		{Prefix: "bob"},
+		{Suffix: "io"},
+		{Prefix: strings.Repeat("q", 33)},
+		{Prefix: strings.Repeat("q", 20), Suffix: strings.Repeat("q", 19)},
+		{Regexp: "["},
+		{Regexp: "^team[bio]"},
+		{Regexp: "abc"},
+		{Regexp: "[bio1]"},
+	} {
+		_, err := NewGenerator(opts)
+		r.True(errors.Is(err, ErrInvalidPattern), "%+v", opts)
+	}
+
+	g, err := NewGenerator(Options{Prefix: "te", Suffix: "am"})
+	r.NoError(err)
+	n, ok := g.ExpectedAttempts()
+	r.True(ok)
+	r.Equal(float64(32*32*32*32), n)
+	r.True(g.Match("io1te" + strings.Repeat("q", 32) + "am"))
+	r.False(g.Match("io1et" + strings.Repeat("q", 32) + "am"))
+	r.False(g.Match("noseparator"))
+
+	g, err = NewGenerator(Options{Regexp: "(?i)^TEAM[a-z]"})
+	r.NoError(err)
+	_, ok = g.ExpectedAttempts()
+	r.False(ok)
+	r.True(g.Match("io1teamq"))
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+// formats of the input
+const (
+	formatBech32    = "bech32"
+	formatLegacy    = "legacy"
+	formatHex       = "hex"
+	formatSpecial   = "special"
+	formatV2        = "v2"
+	formatPublicKey = "pubkey"
+	formatProtocol  = "protocol"
+)
+
+// result holds every representation of an address
+type result struct {
+	Input       string `json:"input"`
+	Format      string `json:"format,omitempty"`
+	Network     string `json:"network,omitempty"`
+	Prefix      string `json:"prefix,omitempty"`
+	Bech32      string `json:"bech32,omitempty"`
+	Hex         string `json:"hex,omitempty"`
+	ChecksumHex string `json:"checksumHex,omitempty"`
+	Special     bool   `json:"special"`
+	Version     int    `json:"version"`
+	Type        string `json:"type,omitempty"`
+	ChainID     uint32 `json:"chainID,omitempty"`
+	Protocol    string `json:"protocol,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+func (r *result) print(w io.Writer) {
+	fmt.Fprintf(w, "input:        %s\n", r.Input)
+	fmt.Fprintf(w, "format:       %s\n", r.Format)
+	fmt.Fprintf(w, "network:      %s\n", r.Network)
+	fmt.Fprintf(w, "prefix:       %s\n", r.Prefix)
+	fmt.Fprintf(w, "bech32:       %s\n", r.Bech32)
+	if !r.Special {
+		fmt.Fprintf(w, "hex:          %s\n", r.Hex)
+		fmt.Fprintf(w, "checksum hex: %s\n", r.ChecksumHex)
+	}
+	fmt.Fprintf(w, "special:      %t\n", r.Special)
+	fmt.Fprintf(w, "version:      %d\n", r.Version)
+	if r.Type != "" {
+		fmt.Fprintf(w, "type:         %s\n", r.Type)
+		fmt.Fprintf(w, "chain ID:     %d\n", r.ChainID)
+	}
+	if r.Protocol != "" {
+		fmt.Fprintf(w, "protocol:     %s\n", r.Protocol)
+	}
+	fmt.Fprintln(w)
+}
+
+// inspect detects the format of the input and decodes it
+func inspect(net address.Network, input string) (*result, error) {
+	if address.IsAddrV1Special(input) {
+		addr, err := address.FromStringOn(address.Mainnet, input)
+		if err != nil {
+			return nil, err
+		}
+		return newResult(input, formatSpecial, address.Mainnet, addr), nil
+	}
+	if isHex(input) {
+		s := input
+		if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+			s = "0x" + s
+		}
+		addr, err := address.FromHexChecked(s)
+		if err != nil {
+			return nil, err
+		}
+		return newResult(input, formatHex, net, addr), nil
+	}
+	// the prefix of a bech32 address determines its network
+	one := strings.LastIndexByte(input, '1')
+	if one < 1 {
+		return nil, errors.Wrap(address.ErrInvalidAddr, "unknown format")
+	}
+	addrNet, ok := address.NetworkFromPrefix(strings.ToLower(input[:one]))
+	if !ok {
+		return nil, errors.Wrapf(address.ErrInvalidAddr, "unknown prefix %s", input[:one])
+	}
+	if addr, err := address.FromStringOn(addrNet, input); err == nil {
+		if addr.Version() == address.Version2 {
+			return newResult(input, formatV2, addrNet, addr), nil
+		}
+		return newResult(input, formatBech32, addrNet, addr), nil
+	}
+	addr, err := address.FromStringLegacyOn(addrNet, input)
+	if err != nil {
+		return nil, err
+	}
+	return newResult(input, formatLegacy, addrNet, addr), nil
+}
+
+// fromPublicKey derives the address of a hex-encoded public key
+func fromPublicKey(net address.Network, input string) (*result, error) {
+	pk, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(input, "0x"), "0X"))
+	if err != nil {
+		return nil, errors.Wrap(address.ErrInvalidPublicKey, err.Error())
+	}
+	addr, err := address.FromPublicKey(pk)
+	if err != nil {
+		return nil, err
+	}
+	return newResult(input, formatPublicKey, net, addr), nil
+}
+
+// fromProtocol returns the address of a registered protocol, or derives it from the hash of the name
+func fromProtocol(net address.Network, input string) (*result, error) {
+	if addr, ok := address.ProtocolByName(input); ok {
+		if _, err := address.TryBytes(addr); err != nil {
+			net = address.Mainnet
+		}
+		return newResult(input, formatProtocol, net, addr), nil
+	}
+	h := address.ProtocolHash(input)
+	addr, err := address.FromBytes(h[:])
+	if err != nil {
+		return nil, err
+	}
+	return newResult(input, formatProtocol, net, addr), nil
+}
+
+func newResult(input, format string, net address.Network, addr address.Address) *result {
+	r := &result{
+		Input:   input,
+		Format:  format,
+		Network: net.Name(),
+		Prefix:  net.Prefix(),
+		Bech32:  address.StringOn(net, addr),
+		Version: addr.Version(),
+	}
+	switch a := addr.(type) {
+	case *address.AddrV1:
+		r.Hex = a.Hex()
+		r.ChecksumHex = a.ChecksumHex()
+	case *address.AddrV2:
+		r.Hex = a.Hex()
+		r.ChecksumHex = a.V1().ChecksumHex()
+		r.Type = a.Type().String()
+		r.ChainID = a.ChainID()
+	default:
+		r.Special = true
+	}
+	if name, _, ok := address.ProtocolByString(address.StringOn(address.DefaultNetwork(), addr)); ok {
+		r.Protocol = name
+	}
+	return r
+}
+
+// isHex returns true for a 20-byte hex string with or without the "0x" prefix
+func isHex(s string) bool {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return true
+	}
+	if len(s) != 40 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// ioaddr converts and inspects IoTeX addresses.
+//
+// Usage:
+//
+//	ioaddr [-json] [-network name] [address ...]
+//	ioaddr pubkey [-json] [-network name] [public key ...]
+//	ioaddr protocol [-json] [-network name] [protocol name ...]
+//	ioaddr vanity [-json] [-network name] [-prefix p] [-suffix s] [-regexp re] [-workers n] [-timeout d] [-quiet]
+//	ioaddr mnemonic [-json] [-network name] [-n count] [-account a] [-passphrase] < mnemonic.txt
+//
+// Addresses may be given in bech32, legacy, hex, or special form, and the format is detected automatically. Public
+// keys are hex-encoded secp256k1 keys, either compressed or uncompressed. If no argument is given, the inputs are
+// read from stdin, one per line. The vanity subcommand searches for a private key whose address matches the pattern.
+// The mnemonic subcommand prints the first addresses of a BIP-39 mnemonic along the path m/44'/304'/account'/0/index.
+// The mnemonic and the optional passphrase are read from stdin one per line, or prompted for without echo on a terminal.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+// command converts an input into an address
+type command struct {
+	name    string
+	usage   string
+	convert func(net address.Network, input string) (*result, error)
+}
+
+var commands = map[string]command{
+	"inspect": {
+		name:    "inspect",
+		usage:   "[address ...]",
+		convert: inspect,
+	},
+	"pubkey": {
+		name:    "pubkey",
+		usage:   "[public key ...]",
+		convert: fromPublicKey,
+	},
+	"protocol": {
+		name:    "protocol",
+		usage:   "[protocol name ...]",
+		convert: fromProtocol,
+	},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) > 0 && args[0] == "vanity" {
+		return runVanity(args[1:], stdout, stderr)
+	}
+	if len(args) > 0 && args[0] == "mnemonic" {
+		return runMnemonic(args[1:], stdin, stdout, stderr)
+	}
+	cmd := commands["inspect"]
+	if len(args) > 0 {
+		if c, ok := commands[args[0]]; ok {
+			cmd, args = c, args[1:]
+		}
+	}
+	fs := flag.NewFlagSet("ioaddr "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "print results as JSON, one object per line")
+	netName := fs.String("network", address.DefaultNetwork().Name(), "network to encode bech32 addresses on: mainnet or testnet")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: ioaddr %s [flags] %s\n", cmd.name, cmd.usage)
+		fmt.Fprintf(stderr, "Subcommands: inspect (default), pubkey, protocol, vanity, mnemonic\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	net, ok := networkByName(*netName)
+	if !ok {
+		fmt.Fprintf(stderr, "unknown network %s\n", *netName)
+		return 2
+	}
+
+	inputs := fs.Args()
+	if len(inputs) == 0 {
+		scanner := bufio.NewScanner(stdin)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				inputs = append(inputs, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			fmt.Fprintf(stderr, "failed to read stdin: %v\n", err)
+			return 1
+		}
+	}
+
+	code := 0
+	for _, input := range inputs {
+		res, err := cmd.convert(net, input)
+		if err != nil {
+			code = 1
+			res = &result{Input: input, Error: err.Error()}
+		}
+		if *asJSON {
+			b, err := json.Marshal(res)
+			if err != nil {
+				fmt.Fprintf(stderr, "failed to encode result: %v\n", err)
+				return 1
+			}
+			fmt.Fprintln(stdout, string(b))
+			continue
+		}
+		if res.Error != "" {
+			fmt.Fprintf(stderr, "%s: %s\n", input, res.Error)
+			continue
+		}
+		res.print(stdout)
+	}
+	return code
+}
+
+func networkByName(name string) (address.Network, bool) {
+	for _, net := range []address.Network{address.Mainnet, address.Testnet} {
+		if strings.EqualFold(name, net.Name()) {
+			return net, true
+		}
+	}
+	return address.Network{}, false
+}
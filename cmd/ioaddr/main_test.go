@@ -0,0 +1,92 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+func TestInspect(t *testing.T) {
+	r := require.New(t)
+
+	for _, v := range []struct {
+		input, format, network, bech32 string
+		special                        bool
+		protocol                       string
+	}{
+		{"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5", formatBech32, "mainnet", "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5", false, ""},
+		{"it1djlzhwxdqqahhwhdxtn9hkhppvnnrptqg05fuh", formatBech32, "testnet", "it1djlzhwxdqqahhwhdxtn9hkhppvnnrptqg05fuh", false, ""},
+		{"io1qp3mxh8gx8fkqmss9c6jsm979wuv6qpm0waw6vhxt0dwzze8xxzkqzy3lxu", formatLegacy, "mainnet", "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5", false, ""},
+		{"0x6cbe2bb8cd003b7bbaed32e65bdae10b27318560", formatHex, "mainnet", "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5", false, ""},
+		{"6cbe2bb8cd003b7bbaed32e65bdae10b27318560", formatHex, "mainnet", "io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5", false, ""},
+		{address.StakingProtocolAddr, formatBech32, "mainnet", address.StakingProtocolAddr, false, address.StakingProtocolName},
+		{address.RewardingPoolAddr, formatSpecial, "mainnet", address.RewardingPoolAddr, true, address.RewardingPoolName},
+		{"it1qgqsqqqqq9ktu2ace5qrk7a6a5ewvk76uy9jwvv9vqfvkydr", formatV2, "testnet", "it1qgqsqqqqq9ktu2ace5qrk7a6a5ewvk76uy9jwvv9vqfvkydr", false, ""},
+	} {
+		res, err := inspect(address.Mainnet, v.input)
+		r.NoError(err)
+		r.Equal(v.format, res.Format)
+		r.Equal(v.network, res.Network)
+		r.Equal(v.bech32, res.Bech32)
+		r.Equal(v.special, res.Special)
+		r.Equal(v.protocol, res.Protocol)
+	}
+
+	for _, v := range []string{
+		"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h4",
+		"0x6cbE2bb8cd003b7bbaed32e65bdae10b27318560",
+		"xx1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5",
+		"hello",
+	} {
+		_, err := inspect(address.Mainnet, v)
+		r.Error(err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	r := require.New(t)
+
+	// batch mode with JSON output
+	var stdout, stderr bytes.Buffer
+	stdin := strings.NewReader("io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h5\n\n  0x6cbe2bb8cd003b7bbaed32e65bdae10b27318560  \n")
+	r.Equal(0, run([]string{"-json", "-network", "testnet"}, stdin, &stdout, &stderr))
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	r.Len(lines, 2)
+	for _, line := range lines {
+		var res result
+		r.NoError(json.Unmarshal([]byte(line), &res))
+		r.Equal("0x6cbe2bb8cd003b7bbaed32e65bdae10b27318560", res.Hex)
+	}
+	var res result
+	r.NoError(json.Unmarshal([]byte(lines[1]), &res))
+	r.Equal("it1djlzhwxdqqahhwhdxtn9hkhppvnnrptqg05fuh", res.Bech32)
+	r.Equal("it", res.Prefix)
+
+	// derivation subcommands
+	stdout.Reset()
+	r.Equal(0, run([]string{"pubkey", "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"}, nil, &stdout, &stderr))
+	r.Contains(stdout.String(), "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf")
+	stdout.Reset()
+	r.Equal(0, run([]string{"protocol", "-json", "staking", "rewardingPool"}, nil, &stdout, &stderr))
+	r.Contains(stdout.String(), address.StakingProtocolAddr)
+	r.Contains(stdout.String(), address.RewardingPoolAddr)
+
+	// errors
+	stderr.Reset()
+	r.Equal(1, run([]string{"io1djlzhwxdqqahhwhdxtn9hkhppvnnrptqtwf2h4"}, nil, &stdout, &stderr))
+	r.Contains(stderr.String(), "checksum failed")
+	r.Equal(1, run([]string{"pubkey", "zz"}, nil, &stdout, &stderr))
+	r.Equal(2, run([]string{"-network", "unknown"}, nil, &stdout, &stderr))
+	r.Equal(2, run([]string{"-unknown"}, nil, &stdout, &stderr))
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-address/address/hd"
+)
+
+// maxMnemonicAddresses bounds the number of addresses the mnemonic subcommand prints
+const maxMnemonicAddresses = 1000
+
+// mnemonicResult is an address derived by the mnemonic subcommand
+type mnemonicResult struct {
+	Path    string `json:"path"`
+	Address string `json:"address"`
+	Hex     string `json:"hex"`
+}
+
+// runMnemonic prints the first addresses of a BIP-39 mnemonic, and returns the exit code
+// Only addresses are printed, so that a recovery can be verified without revealing the keys. The mnemonic and the
+// passphrase are read from stdin, or prompted for without echo on a terminal, so that they leak into neither the shell
+// history nor the process list
+func runMnemonic(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("ioaddr mnemonic", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "print results as JSON, one object per line")
+	netName := fs.String("network", address.DefaultNetwork().Name(), "network to encode bech32 addresses on: mainnet or testnet")
+	count := fs.Uint("n", 5, fmt.Sprintf("number of addresses to print, at most %d", maxMnemonicAddresses))
+	account := fs.Uint("account", 0, "account of the path m/44'/304'/account'/0/index")
+	withPassphrase := fs.Bool("passphrase", false, "read a BIP-39 passphrase after the mnemonic, if the wallet has one")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: ioaddr mnemonic [flags] < mnemonic.txt\n")
+		fmt.Fprintf(stderr, "The mnemonic is read from the first line of stdin, and the passphrase from the second line.\n")
+		fmt.Fprintf(stderr, "On a terminal, both are prompted for without echo\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "the mnemonic must be given through stdin, as arguments leak into the shell history\n")
+		fs.Usage()
+		return 2
+	}
+	net, ok := networkByName(*netName)
+	if !ok {
+		fmt.Fprintf(stderr, "unknown network %s\n", *netName)
+		return 2
+	}
+	if *account >= uint(hd.HardenedOffset) || *count > maxMnemonicAddresses {
+		fmt.Fprintf(stderr, "account %d or count %d out of range\n", *account, *count)
+		return 2
+	}
+
+	read := newSecretReader(stdin, stderr)
+	mnemonic, err := read("Mnemonic: ")
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to read mnemonic: %v\n", err)
+		return 1
+	}
+	var passphrase string
+	if *withPassphrase {
+		if passphrase, err = read("Passphrase: "); err != nil {
+			fmt.Fprintf(stderr, "failed to read passphrase: %v\n", err)
+			return 1
+		}
+	}
+	master, err := hd.NewMasterFromMnemonic(mnemonic, passphrase)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	path := hd.IoTeXPath(uint32(*account), 0, 0)
+	key, err := master.Derive(path[:4])
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	addrs, err := hd.DeriveAddresses(key, 0, uint32(*count))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	for i, addr := range addrs {
+		path[4] = uint32(i)
+		res := mnemonicResult{
+			Path:    path.String(),
+			Address: address.StringOn(net, addr),
+			Hex:     addr.(*address.AddrV1).ChecksumHex(),
+		}
+		if *asJSON {
+			b, err := json.Marshal(&res)
+			if err != nil {
+				fmt.Fprintf(stderr, "failed to encode result: %v\n", err)
+				return 1
+			}
+			fmt.Fprintln(stdout, string(b))
+			continue
+		}
+		fmt.Fprintf(stdout, "%s  %s  %s\n", res.Path, res.Address, res.Hex)
+	}
+	return 0
+}
+
+// newSecretReader returns a function reading a secret line by line from stdin
+// If stdin is a terminal, the prompt is written to stderr, and the input is not echoed
+func newSecretReader(stdin io.Reader, stderr io.Writer) func(prompt string) (string, error) {
+	if f, ok := stdin.(*os.File); ok && terminal.IsTerminal(int(f.Fd())) {
+		return func(prompt string) (string, error) {
+			fmt.Fprint(stderr, prompt)
+			b, err := terminal.ReadPassword(int(f.Fd()))
+			fmt.Fprintln(stderr)
+			return string(b), err
+		}
+	}
+	if stdin == nil {
+		stdin = strings.NewReader("")
+	}
+	br := bufio.NewReader(stdin)
+	return func(string) (string, error) {
+		line, err := br.ReadString('\n')
+		if err == io.EOF && line != "" {
+			err = nil
+		}
+		return strings.TrimRight(line, "\r\n"), err
+	}
+}
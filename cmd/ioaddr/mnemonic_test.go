@@ -0,0 +1,63 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMnemonic(t *testing.T) {
+	r := require.New(t)
+
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	var stdout, stderr bytes.Buffer
+	r.Equal(0, run([]string{"mnemonic", "-n", "2"}, strings.NewReader(mnemonic), &stdout, &stderr))
+	r.Equal("m/44'/304'/0'/0/0  io1r5ua6qf5ygpmt6deckczqk56c2ug0nsqehptsx  0x1D39DD01342203b5E9b9C5b0205a9aC2b887cE00\n"+
+		"m/44'/304'/0'/0/1  io15as560k8u2jdd4a62teds4rkyl060hcpauc8tn  0xa7614d3eC7E2A4D6d7Ba52F2D8547627dFA7df01\n",
+		stdout.String())
+
+	// passphrase on the second line, with account
+	stdout.Reset()
+	r.Equal(0, run([]string{"mnemonic", "-json", "-n", "1", "-network", "testnet", "-account", "1", "-passphrase"},
+		strings.NewReader("legal winner thank year wave sausage worth useful legal winner thank yellow\r\nTREZOR\n"), &stdout, &stderr))
+	var res mnemonicResult
+	r.NoError(json.Unmarshal(stdout.Bytes(), &res))
+	r.Equal(mnemonicResult{
+		Path:    "m/44'/304'/1'/0/0",
+		Address: "it1s6z68qw5r97p7ucvtrcp5w6zv6lle3ugpmq444",
+		Hex:     "0x8685a381d4197C1f730C58F01a3B4266BfFCc788",
+	}, res)
+
+	stdout.Reset()
+	r.Equal(1, run([]string{"mnemonic"}, strings.NewReader("abandon abandon\n"), &stdout, &stderr))
+	r.Contains(stderr.String(), "invalid mnemonic")
+	r.Empty(stdout.String())
+	stderr.Reset()
+	r.Equal(1, run([]string{"mnemonic", "-passphrase"}, strings.NewReader(mnemonic+"\n"), &stdout, &stderr))
+	r.Contains(stderr.String(), "failed to read passphrase")
+	r.Empty(stdout.String())
+
+	// secrets are not accepted as arguments, and the count is bounded
+	for _, args := range [][]string{
+		append([]string{"mnemonic"}, strings.Fields(mnemonic)...),
+		{"mnemonic", "-passphrase", "TREZOR"},
+		{"mnemonic", "-n", "1001"},
+		{"mnemonic", "-n", "2147483648"},
+		{"mnemonic", "-account", "2147483648"},
+		{"mnemonic", "-network", "nowhere"},
+	} {
+		stderr.Reset()
+		r.Equal(2, run(args, strings.NewReader(mnemonic), &stdout, &stderr), args)
+		r.NotEmpty(stderr.String())
+		r.Empty(stdout.String())
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-address/address/vanity"
+)
+
+// vanityResult is the key found by the vanity subcommand
+type vanityResult struct {
+	Address    string `json:"address"`
+	Hex        string `json:"hex"`
+	PrivateKey string `json:"privateKey"`
+	Attempts   uint64 `json:"attempts"`
+}
+
+// runVanity searches for a key whose address matches the pattern, and returns the exit code
+func runVanity(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("ioaddr vanity", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "print the result as JSON")
+	netName := fs.String("network", address.DefaultNetwork().Name(),
+		"network to encode bech32 addresses on: mainnet or testnet")
+	prefix := fs.String("prefix", "", "prefix right after the separator, e.g., team for io1team...")
+	suffix := fs.String("suffix", "", "suffix at the end of the address")
+	re := fs.String("regexp", "",
+		"regular expression matched against the address without the network prefix and separator")
+	workers := fs.Int("workers", 0, "number of goroutines, 0 means the number of CPUs")
+	timeout := fs.Duration("timeout", 0, "give up after the duration, 0 means no timeout")
+	quiet := fs.Bool("quiet", false, "do not report progress")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: ioaddr vanity [flags]\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	net, ok := networkByName(*netName)
+	if !ok {
+		fmt.Fprintf(stderr, "unknown network %s\n", *netName)
+		return 2
+	}
+	var (
+		start    = time.Now()
+		expected float64
+		known    bool
+	)
+	opts := vanity.Options{
+		Prefix:  *prefix,
+		Suffix:  *suffix,
+		Regexp:  *re,
+		Network: net,
+		Workers: *workers,
+	}
+	if !*quiet {
+		opts.Progress = func(attempts uint64) {
+			rate := float64(attempts) / time.Since(start).Seconds()
+			if known {
+				fmt.Fprintf(stderr, "%d attempts, %.0f/s, %.1f%% of expected\n", attempts, rate, 100*float64(attempts)/expected)
+				return
+			}
+			fmt.Fprintf(stderr, "%d attempts, %.0f/s\n", attempts, rate)
+		}
+	}
+	g, err := vanity.NewGenerator(opts)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if expected, known = g.ExpectedAttempts(); known && !*quiet {
+		fmt.Fprintf(stderr, "expected attempts: %.0f\n", expected)
+	}
+
+	// stop on interrupt or timeout, the goroutine only using ctx and cancel of the interrupt, which are not reassigned
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	searchCtx := ctx
+	if *timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		searchCtx, cancelTimeout = context.WithTimeout(ctx, *timeout)
+		defer cancelTimeout()
+	}
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	go func() {
+		select {
+		case <-interrupt:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	res, err := g.Search(searchCtx)
+	if err != nil {
+		fmt.Fprintf(stderr, "search stopped: %v\n", err)
+		return 1
+	}
+	out := vanityResult{
+		Address:    res.Address.StringOn(net),
+		Hex:        res.Address.ChecksumHex(),
+		PrivateKey: hex.EncodeToString(res.PrivateKey),
+		Attempts:   res.Attempts,
+	}
+	if *asJSON {
+		b, err := json.Marshal(&out)
+		if err != nil {
+			fmt.Fprintf(stderr, "failed to encode result: %v\n", err)
+			return 1
+		}
+		fmt.Fprintln(stdout, string(b))
+		return 0
+	}
+	fmt.Fprintf(stdout, "address:      %s\n", out.Address)
+	fmt.Fprintf(stdout, "hex:          %s\n", out.Hex)
+	fmt.Fprintf(stdout, "private key:  %s\n", out.PrivateKey)
+	fmt.Fprintf(stdout, "attempts:     %d\n", out.Attempts)
+	return 0
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+func TestVanity(t *testing.T) {
+	r := require.New(t)
+
+	var stdout, stderr bytes.Buffer
+	r.Equal(0, run([]string{"vanity", "-json", "-quiet", "-network", "testnet", "-prefix", "q", "-workers", "1"}, nil, &stdout, &stderr))
+	var res vanityResult
+	r.NoError(json.Unmarshal(stdout.Bytes(), &res))
+	r.True(strings.HasPrefix(res.Address, "it1q"))
+	r.True(res.Attempts > 0)
+	key, err := hex.DecodeString(res.PrivateKey)
+	r.NoError(err)
+	r.Len(key, 32)
+	addr, err := address.FromHexChecked(res.Hex)
+	r.NoError(err)
+	r.Equal(res.Address, address.StringOn(address.Testnet, addr))
+
+	// progress and expected attempts are reported on stderr
+	stdout.Reset()
+	stderr.Reset()
+	r.Equal(0, run([]string{"vanity", "-suffix", "q"}, nil, &stdout, &stderr))
+	r.Contains(stderr.String(), "expected attempts: 32")
+	r.Contains(stdout.String(), "private key:")
+
+	for _, args := range [][]string{
+		{"vanity"},
+		{"vanity", "-prefix", "bio"},
+		{"vanity", "-network", "nowhere", "-prefix", "q"},
+	} {
+		stderr.Reset()
+		r.Equal(2, run(args, nil, &stdout, &stderr))
+		r.NotEmpty(stderr.String())
+	}
+	stderr.Reset()
+	r.Equal(1, run([]string{"vanity", "-quiet", "-prefix", strings.Repeat("q", 20), "-timeout", "50ms"}, nil, &stdout, &stderr))
+	r.Contains(stderr.String(), "search stopped")
+}
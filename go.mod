@@ -3,6 +3,7 @@ module github.com/iotexproject/iotex-address
 go 1.14
 
 require (
+	github.com/decred/dcrd/dcrec/secp256k1/v3 v3.0.0
 	github.com/pkg/errors v0.9.1
 	github.com/stretchr/testify v1.4.0
 	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9